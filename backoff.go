@@ -0,0 +1,100 @@
+package ifrit
+
+import (
+	"math/rand"
+	"time"
+)
+
+/*
+A Backoff determines how long to wait between restarts.  Next returns the delay
+before the next restart, and Reset returns the Backoff to its initial delay.
+*/
+type Backoff interface {
+	Next() time.Duration
+	Reset()
+}
+
+/*
+ConstantBackoff always waits for the same delay.
+*/
+func ConstantBackoff(delay time.Duration) Backoff {
+	return constantBackoff(delay)
+}
+
+type constantBackoff time.Duration
+
+func (b constantBackoff) Next() time.Duration {
+	return time.Duration(b)
+}
+
+func (b constantBackoff) Reset() {}
+
+/*
+ExponentialBackoff waits for initial, and multiplies the delay by factor after
+each wait, up to max.  A factor below one is treated as one.
+*/
+func ExponentialBackoff(initial, max time.Duration, factor float64) Backoff {
+	if factor < 1 {
+		factor = 1
+	}
+
+	return &exponentialBackoff{
+		initial: initial,
+		max:     max,
+		factor:  factor,
+		next:    initial,
+	}
+}
+
+type exponentialBackoff struct {
+	initial time.Duration
+	max     time.Duration
+	factor  float64
+	next    time.Duration
+}
+
+func (b *exponentialBackoff) Next() time.Duration {
+	delay := b.next
+	if delay > b.max {
+		delay = b.max
+	}
+
+	next := time.Duration(float64(delay) * b.factor)
+	if next > b.max || next < delay {
+		next = b.max
+	}
+	b.next = next
+
+	return delay
+}
+
+func (b *exponentialBackoff) Reset() {
+	b.next = b.initial
+}
+
+/*
+WithJitter randomizes each delay of the backoff by up to plus or minus
+fraction of it, so that replicas restarting together spread out rather than
+retrying in lockstep.  A fraction of 0.1 varies each delay by up to 10%.
+*/
+func WithJitter(backoff Backoff, fraction float64) Backoff {
+	return jitterBackoff{
+		backoff:  backoff,
+		fraction: fraction,
+	}
+}
+
+type jitterBackoff struct {
+	backoff  Backoff
+	fraction float64
+}
+
+func (b jitterBackoff) Next() time.Duration {
+	delay := b.backoff.Next()
+	jitter := b.fraction * (2*rand.Float64() - 1)
+	return time.Duration(float64(delay) * (1 + jitter))
+}
+
+func (b jitterBackoff) Reset() {
+	b.backoff.Reset()
+}
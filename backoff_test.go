@@ -0,0 +1,75 @@
+package ifrit_test
+
+import (
+	"time"
+
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backoff", func() {
+	Describe("ConstantBackoff", func() {
+		It("always returns the same delay", func() {
+			backoff := ifrit.ConstantBackoff(time.Second)
+			Ω(backoff.Next()).Should(Equal(time.Second))
+			Ω(backoff.Next()).Should(Equal(time.Second))
+
+			backoff.Reset()
+			Ω(backoff.Next()).Should(Equal(time.Second))
+		})
+	})
+
+	Describe("ExponentialBackoff", func() {
+		It("grows by the factor, up to the maximum", func() {
+			backoff := ifrit.ExponentialBackoff(time.Second, 10*time.Second, 2)
+
+			delays := []time.Duration{}
+			for i := 0; i < 6; i++ {
+				delays = append(delays, backoff.Next())
+			}
+			Ω(delays).Should(Equal([]time.Duration{
+				1 * time.Second,
+				2 * time.Second,
+				4 * time.Second,
+				8 * time.Second,
+				10 * time.Second,
+				10 * time.Second,
+			}))
+		})
+
+		It("returns to the initial delay when reset", func() {
+			backoff := ifrit.ExponentialBackoff(time.Second, 10*time.Second, 2)
+			backoff.Next()
+			backoff.Next()
+
+			backoff.Reset()
+			Ω(backoff.Next()).Should(Equal(time.Second))
+		})
+	})
+
+	Describe("WithJitter", func() {
+		It("keeps every delay within the fraction of the underlying delay", func() {
+			backoff := ifrit.WithJitter(ifrit.ConstantBackoff(time.Second), 0.1)
+
+			distinct := map[time.Duration]struct{}{}
+			for i := 0; i < 1000; i++ {
+				delay := backoff.Next()
+				Ω(delay).Should(BeNumerically(">=", 900*time.Millisecond))
+				Ω(delay).Should(BeNumerically("<=", 1100*time.Millisecond))
+				distinct[delay] = struct{}{}
+			}
+			Ω(len(distinct)).Should(BeNumerically(">", 1))
+		})
+
+		It("resets the underlying backoff", func() {
+			backoff := ifrit.WithJitter(ifrit.ExponentialBackoff(time.Second, time.Minute, 10), 0.1)
+			backoff.Next()
+			backoff.Next()
+
+			backoff.Reset()
+			Ω(backoff.Next()).Should(BeNumerically("<=", 1100*time.Millisecond))
+		})
+	})
+})
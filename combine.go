@@ -0,0 +1,77 @@
+package ifrit
+
+import "os"
+
+/*
+Combine returns a Runner which runs the given runners concurrently, as a single
+anonymous unit.  It becomes ready once every runner is ready, and forwards every
+signal it receives to each of them.  It is a lightweight alternative to a
+grouper group, for runners which do not need names or events.
+
+If a runner exits with an error, or exits before the combined runner is ready,
+the others are sent os.Interrupt.  Run returns once every runner has exited,
+with the first error any of them returned.
+*/
+func Combine(runners ...Runner) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		if len(runners) == 0 {
+			close(ready)
+			<-signals
+			return nil
+		}
+
+		readies := make(chan struct{}, len(runners))
+		exits := make(chan error, len(runners))
+
+		processes := make([]Process, 0, len(runners))
+		for _, runner := range runners {
+			p := Background(runner)
+			processes = append(processes, p)
+
+			go func(p Process) {
+				select {
+				case <-p.Ready():
+					readies <- struct{}{}
+				case <-p.Exited():
+				}
+			}(p)
+			go func(p Process) {
+				exits <- <-p.Wait()
+			}(p)
+		}
+
+		stopping := false
+		signalAll := func(signal os.Signal) {
+			stopping = true
+			for _, p := range processes {
+				p.Signal(signal)
+			}
+		}
+
+		var firstErr error
+		numReady := 0
+		for numExited := 0; numExited < len(runners); {
+			select {
+			case signal := <-signals:
+				signalAll(signal)
+
+			case <-readies:
+				numReady++
+				if numReady == len(runners) && !stopping {
+					close(ready)
+				}
+
+			case err := <-exits:
+				numExited++
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if !stopping && (err != nil || numReady < len(runners)) {
+					signalAll(os.Interrupt)
+				}
+			}
+		}
+
+		return firstErr
+	})
+}
@@ -0,0 +1,80 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("Combine", func() {
+	var (
+		runner1 *fake_runner.TestRunner
+		runner2 *fake_runner.TestRunner
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		runner1 = fake_runner.NewTestRunner()
+		runner2 = fake_runner.NewTestRunner()
+		process = ifrit.Background(ifrit.Combine(runner1, runner2))
+	})
+
+	AfterEach(func() {
+		runner1.EnsureExit()
+		runner2.EnsureExit()
+		Eventually(process.Exited()).Should(BeClosed())
+	})
+
+	It("becomes ready once every runner is ready", func() {
+		runner1.TriggerReady()
+		Consistently(process.Ready()).ShouldNot(BeClosed())
+
+		runner2.TriggerReady()
+		Eventually(process.Ready()).Should(BeClosed())
+	})
+
+	It("forwards signals to every runner", func() {
+		signals1 := runner1.WaitForCall()
+		signals2 := runner2.WaitForCall()
+
+		process.Signal(syscall.SIGUSR2)
+		Eventually(signals1).Should(Receive(Equal(syscall.SIGUSR2)))
+		Eventually(signals2).Should(Receive(Equal(syscall.SIGUSR2)))
+
+		runner1.TriggerExit(nil)
+		runner2.TriggerExit(nil)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("stops the other runners, and returns the first error, when a runner fails", func() {
+		runner1.TriggerReady()
+		runner2.TriggerReady()
+		Eventually(process.Ready()).Should(BeClosed())
+		signals2 := runner2.WaitForCall()
+
+		runner1.TriggerExit(errors.New("boom"))
+		Eventually(signals2).Should(Receive(Equal(os.Interrupt)))
+		Consistently(process.Wait()).ShouldNot(Receive())
+
+		runner2.TriggerExit(errors.New("stopped"))
+		Eventually(process.Wait()).Should(Receive(MatchError("boom")))
+	})
+
+	It("keeps the other runners when a ready runner exits cleanly", func() {
+		runner1.TriggerReady()
+		runner2.TriggerReady()
+		Eventually(process.Ready()).Should(BeClosed())
+		signals2 := runner2.WaitForCall()
+
+		runner1.TriggerExit(nil)
+		Consistently(signals2).ShouldNot(Receive())
+
+		runner2.TriggerExit(nil)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+})
@@ -0,0 +1,63 @@
+package ifrit
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+/*
+NewCommand wraps an exec.Cmd as a Runner.  The command is started when the
+Runner is run, and the Runner becomes ready as soon as the command is running.
+Signals received by the Runner are forwarded to the command, and the Runner
+returns the command's exit error, an *exec.ExitError, which is an ExitCoder.
+Like the exec.Cmd it wraps, the Runner may only be run once.
+*/
+func NewCommand(cmd *exec.Cmd) Runner {
+	return Command{Cmd: cmd}
+}
+
+var _ ExitCoder = (*exec.ExitError)(nil)
+
+/*
+Command implements NewCommand.  If KillTimeout is set, a command which has not
+exited KillTimeout after it was first signaled is killed.
+*/
+type Command struct {
+	Cmd         *exec.Cmd
+	KillTimeout time.Duration
+}
+
+func (c Command) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := c.Cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- c.Cmd.Wait()
+	}()
+
+	close(ready)
+
+	var kill <-chan time.Time
+
+	for {
+		select {
+		case signal := <-signals:
+			c.Cmd.Process.Signal(signal)
+			if kill == nil && c.KillTimeout > 0 {
+				timer := time.NewTimer(c.KillTimeout)
+				defer timer.Stop()
+				kill = timer.C
+			}
+
+		case <-kill:
+			c.Cmd.Process.Kill()
+
+		case err := <-exited:
+			return err
+		}
+	}
+}
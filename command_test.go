@@ -0,0 +1,105 @@
+package ifrit_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Command", func() {
+	var proc ifrit.Process
+
+	AfterEach(func() {
+		proc.Signal(os.Kill)
+		Eventually(proc.Wait()).Should(Receive())
+	})
+
+	Context("when the command exits cleanly", func() {
+		BeforeEach(func() {
+			proc = ifrit.Invoke(ifrit.NewCommand(exec.Command("true")))
+		})
+
+		It("returns nil", func() {
+			Eventually(proc.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when the command exits with an error", func() {
+		BeforeEach(func() {
+			proc = ifrit.Invoke(ifrit.NewCommand(exec.Command("sh", "-c", "exit 3")))
+		})
+
+		It("returns the exit error", func() {
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+
+			var exitErr *exec.ExitError
+			Ω(errors.As(err, &exitErr)).Should(BeTrue())
+			Ω(exitErr.ExitCode()).Should(Equal(3))
+		})
+
+		It("returns an ExitCoder, even when wrapped", func() {
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+
+			code, ok := ifrit.ExitCode(fmt.Errorf("member failed: %w", err))
+			Ω(ok).Should(BeTrue())
+			Ω(code).Should(Equal(3))
+		})
+	})
+
+	Context("when the command cannot be started", func() {
+		BeforeEach(func() {
+			proc = ifrit.Background(ifrit.NewCommand(exec.Command("/does/not/exist")))
+		})
+
+		It("returns the error without becoming ready", func() {
+			Eventually(proc.Wait()).Should(Receive(HaveOccurred()))
+			Ω(proc.Ready()).ShouldNot(BeClosed())
+		})
+	})
+
+	Context("when signaled", func() {
+		BeforeEach(func() {
+			proc = ifrit.Invoke(ifrit.NewCommand(exec.Command("sleep", "10")))
+		})
+
+		It("forwards the signal to the command", func() {
+			Ω(proc.Ready()).Should(BeClosed())
+			proc.Signal(os.Interrupt)
+
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+			status := err.(*exec.ExitError).Sys().(syscall.WaitStatus)
+			Ω(status.Signal()).Should(Equal(syscall.SIGINT))
+		})
+	})
+
+	Context("when the command ignores the signal", func() {
+		BeforeEach(func() {
+			output := gbytes.NewBuffer()
+			cmd := exec.Command("sh", "-c", `trap "" INT; echo trapped; while true; do sleep 0.01; done`)
+			cmd.Stdout = output
+			proc = ifrit.Invoke(ifrit.Command{Cmd: cmd, KillTimeout: 50 * time.Millisecond})
+			Eventually(output).Should(gbytes.Say("trapped"))
+		})
+
+		It("kills the command after the timeout", func() {
+			proc.Signal(os.Interrupt)
+			Consistently(proc.Wait(), 20*time.Millisecond).ShouldNot(Receive())
+
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+			status := err.(*exec.ExitError).Sys().(syscall.WaitStatus)
+			Ω(status.Signal()).Should(Equal(syscall.SIGKILL))
+		})
+	})
+})
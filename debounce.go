@@ -0,0 +1,61 @@
+package ifrit
+
+import (
+	"os"
+	"time"
+)
+
+/*
+DebounceReady wraps a Runner, delaying readiness until the inner Runner has been
+continuously ready for the stable duration.  If the inner Runner exits during
+that window, its error is returned and the wrapper never becomes ready.  Signals
+are forwarded to the inner Runner.
+*/
+func DebounceReady(stable time.Duration, inner Runner) Runner {
+	return ReadyDebouncer{
+		Runner: inner,
+		Stable: stable,
+		After:  time.After,
+	}
+}
+
+/*
+ReadyDebouncer implements DebounceReady.  After is used to wait out the stable
+duration, and can be replaced to control the passage of time in tests.
+*/
+type ReadyDebouncer struct {
+	Runner Runner
+	Stable time.Duration
+	After  func(time.Duration) <-chan time.Time
+}
+
+func (d ReadyDebouncer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	after := d.After
+	if after == nil {
+		after = time.After
+	}
+
+	process := Background(d.Runner)
+	processReady := process.Ready()
+	exit := process.Wait()
+
+	var stable <-chan time.Time
+
+	for {
+		select {
+		case signal := <-signals:
+			process.Signal(signal)
+
+		case <-processReady:
+			processReady = nil
+			stable = after(d.Stable)
+
+		case <-stable:
+			stable = nil
+			close(ready)
+
+		case err := <-exit:
+			return err
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("DebounceReady", func() {
+	var (
+		innerRunner *fake_runner.TestRunner
+		debouncer   ifrit.ReadyDebouncer
+		process     ifrit.Process
+
+		requestedDurations chan time.Duration
+		elapsed            chan time.Time
+	)
+
+	BeforeEach(func() {
+		innerRunner = fake_runner.NewTestRunner()
+		requestedDurations = make(chan time.Duration, 1)
+		elapsed = make(chan time.Time)
+
+		debouncer = ifrit.DebounceReady(time.Second, innerRunner).(ifrit.ReadyDebouncer)
+		debouncer.After = func(d time.Duration) <-chan time.Time {
+			requestedDurations <- d
+			return elapsed
+		}
+
+		process = ifrit.Background(debouncer)
+	})
+
+	AfterEach(func() {
+		innerRunner.EnsureExit()
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("becomes ready once the inner runner has been ready for the stable duration", func() {
+		Consistently(process.Ready()).ShouldNot(BeClosed())
+
+		innerRunner.TriggerReady()
+		Eventually(requestedDurations).Should(Receive(Equal(time.Second)))
+		Consistently(process.Ready()).ShouldNot(BeClosed())
+
+		elapsed <- time.Now()
+		Eventually(process.Ready()).Should(BeClosed())
+	})
+
+	It("returns the inner error without becoming ready when the inner runner exits within the window", func() {
+		innerRunner.TriggerReady()
+		Eventually(requestedDurations).Should(Receive())
+
+		innerRunner.TriggerExit(errors.New("flapped"))
+		Eventually(process.Wait()).Should(Receive(Equal(errors.New("flapped"))))
+		Ω(process.Ready()).ShouldNot(BeClosed())
+	})
+
+	It("forwards signals to the inner runner", func() {
+		signals := innerRunner.WaitForCall()
+		process.Signal(os.Interrupt)
+		Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+	})
+})
@@ -0,0 +1,25 @@
+package ifrit
+
+import "errors"
+
+/*
+ExitCoder is implemented by errors which carry a process exit code, such as the
+*exec.ExitError returned by a Command.  Use ExitCode to extract it from an error
+which may have been wrapped.
+*/
+type ExitCoder interface {
+	ExitCode() int
+}
+
+/*
+ExitCode returns the exit code of the first ExitCoder in err's chain, as found
+by errors.As.  It returns false if err does not carry an exit code.  A command
+which was terminated by a signal has an exit code of -1.
+*/
+func ExitCode(err error) (int, bool) {
+	var coder ExitCoder
+	if !errors.As(err, &coder) {
+		return 0, false
+	}
+	return coder.ExitCode(), true
+}
@@ -1,7 +1,12 @@
 package grouper
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
@@ -18,18 +23,86 @@ type DynamicClient interface {
 	/*
 	   EntranceListener provides a new buffered channel of entrance events, which are
 	   emited every time an inserted process is ready. To help prevent race conditions,
-	   every new channel is populated with previously emited events, up to it's buffer
+	   every new channel is populated with previously emited events, up to its buffer
 	   size.
 	*/
 	EntranceListener() <-chan EntranceEvent
 
+	/*
+	   EntranceListenerFor behaves like EntranceListener, but only emits entrance
+	   events for the named members, including previously emitted events.
+	*/
+	EntranceListenerFor(names ...string) <-chan EntranceEvent
+
 	/*
 	   ExitListener provides a new buffered channel of exit events, which are emited
 	   every time an inserted process is ready. To help prevent race conditions, every
-	   new channel is populated with previously emited events, up to it's buffer size.
+	   new channel is populated with previously emited events, up to its buffer size.
 	*/
 	ExitListener() <-chan ExitEvent
 
+	/*
+	   EntranceListenerWithPolicy and ExitListenerWithPolicy behave like
+	   EntranceListener and ExitListener, but handle a full channel as the
+	   OverflowPolicy directs, rather than blocking the group.
+	*/
+	EntranceListenerWithPolicy(policy OverflowPolicy) <-chan EntranceEvent
+	ExitListenerWithPolicy(policy OverflowPolicy) <-chan ExitEvent
+
+	/*
+	   EntranceListenerCtx and ExitListenerCtx behave like EntranceListener and
+	   ExitListener, but the listener is detached, and its channel closed, once
+	   the context is done. A broadcast which is blocked on the listener gives
+	   up, so an abandoned listener can not stall the group.
+	*/
+	EntranceListenerCtx(ctx context.Context) <-chan EntranceEvent
+	ExitListenerCtx(ctx context.Context) <-chan ExitEvent
+
+	/*
+	   DetachEntranceListener and DetachExitListener remove a listener, so that
+	   it receives no further events, and close its channel. Detaching a
+	   listener which is not attached, or has been closed, does nothing. A
+	   broadcast blocked on the listener is waited for, so drain a stalled
+	   listener while detaching it, or use a context listener instead.
+	*/
+	DetachEntranceListener(listener <-chan EntranceEvent)
+	DetachExitListener(listener <-chan ExitEvent)
+
+	/*
+	   CountListener provides a new channel of the number of running members,
+	   which emits the current count when attached, and the new count every time
+	   a member is started or exits. Counts are conflated: a slow reader receives
+	   only the latest count, rather than every change. The channel is closed
+	   once the group exits.
+	*/
+	CountListener() <-chan int
+
+	/*
+	   WaitForCount blocks until at least n members are running, as reported
+	   by CountListener, and returns nil. As counts are conflated, a count
+	   which is held only briefly may be missed. It returns the context's
+	   error if the context is done first, and ErrCountNotReached if the group
+	   exits first.
+	*/
+	WaitForCount(ctx context.Context, n int) error
+
+	/*
+	   ExpectMembers sets the number of members the group expects to become
+	   ready. Once that many members have become ready, counting from when the
+	   group started, the group emits a single GroupReadyEvent. If enough
+	   members are already ready, it is emitted at once. Changing the expected
+	   count after the event has been emitted has no effect.
+	*/
+	ExpectMembers(n int)
+
+	/*
+	   GroupReadyListener provides a new channel, which emits the group's
+	   GroupReadyEvent, even if it occurred before the listener was attached.
+	   The channel is closed once the group exits, without an event if the
+	   expected number of members never became ready.
+	*/
+	GroupReadyListener() <-chan GroupReadyEvent
+
 	/*
 	   CloseNotifier provides a new unbuffered channel, which will emit a single event
 	   once the group has been closed.
@@ -42,14 +115,211 @@ type DynamicClient interface {
 	*/
 	Inserter() chan<- Member
 
+	/*
+	   InsertAll inserts every member, or none of them. The members are validated
+	   as by Members.Validate, and if any name is already running, it returns an
+	   error of type ErrDuplicateNames. Like the insert channel, it blocks while the group is
+	   full; members which do not yet fit are started, in order, as capacity is
+	   released. Once the group is closed, it returns ErrMemberRejected.
+	*/
+	InsertAll(members Members) error
+
+	/*
+	   Replace starts the new member alongside the named old member, even if the
+	   group is full, and waits for it to become ready before stopping the old
+	   member as StopMember does. If the new member exits before becoming ready,
+	   the old member is left running, and Replace returns a StartupError. It
+	   returns ErrMemberNotFound if the old member is not present.
+	*/
+	Replace(oldName string, newMember Member) error
+
+	/*
+	   Pause stops the group from accepting new members until Resume is called.
+	   Running members are not affected, and members already waiting for
+	   capacity are still started. While paused, the insert channel and InsertAll
+	   block, unless the group is configured to RejectWhilePaused. Unlike Close,
+	   a pause may be undone.
+	*/
+	Pause()
+
+	/*
+	   Resume allows a paused group to accept new members again.
+	*/
+	Resume()
+
 	/*
 	   Close causes a dynamic group to become a static group. This means that no new
 	   members may be inserted, and the group will exit once all members have
-	   completed.
+	   completed. Close may be called any number of times, from any goroutine.
 	*/
 	Close()
 
+	/*
+	   Closed reports whether the group has been closed, and no longer accepts new
+	   members. A signaled group is also closed.
+	*/
+	Closed() bool
+
+	/*
+	   Signaled reports whether the group has begun to shut down, either because
+	   it was signaled, or because it propagated its termination signal.
+	*/
+	Signaled() bool
+
 	Get(name string) (ifrit.Process, bool)
+
+	/*
+	   SignalMember sends a signal to a single member of the group. The member's exit
+	   is handled like any other exit. If the member is not present, SignalMember
+	   returns ErrMemberNotFound.
+	*/
+	SignalMember(name string, signal os.Signal) error
+
+	/*
+	   StopMember sends the group's termination signal to a single member, or
+	   os.Interrupt if the group has no termination signal. If the member is not
+	   present, StopMember returns ErrMemberNotFound.
+	*/
+	StopMember(name string) error
+
+	/*
+	   Uptime returns how long the named member has been ready. It returns false if
+	   the member is not present, or has not yet become ready.
+	*/
+	Uptime(name string) (time.Duration, bool)
+
+	/*
+	   WaitMember blocks until the named member exits, and returns its exit error.
+	   A member which has already exited is found in the exit event buffer. It
+	   returns false if the member is neither present nor in the buffer.
+	*/
+	WaitMember(name string) (error, bool)
+
+	/*
+	   SetTerminationSignal changes the signal the group propagates when a member
+	   exits before being signaled. A nil signal is not propagated. Signals which
+	   have already been sent are not affected. It has no effect once the group
+	   has exited.
+	*/
+	SetTerminationSignal(signal os.Signal)
+
+	/*
+	   Stats returns a snapshot of the group's counters since it started. It may be
+	   called at any time, including after the group has exited.
+	*/
+	Stats() GroupStats
+
+	/*
+	   PendingCount returns the number of members which have been inserted, but
+	   not yet started: members the group has accepted, which are waiting for
+	   capacity, and members passed to InsertAll calls which are blocked until
+	   the group accepts them. It is accurate with any number of concurrent
+	   InsertAll calls. A send which is blocked on the insert channel can not be
+	   observed, and is not counted. It returns zero once the group has exited.
+	*/
+	PendingCount() int
+
+	/*
+	   Select returns the status of the running members whose labels match the
+	   selector, ordered by name. The selector is a comma-separated list of
+	   terms, all of which must match: "key=value" matches a label with that
+	   value, and a bare "key" matches any member with that label. An empty
+	   selector matches every member. It returns nothing once the group has
+	   exited.
+	*/
+	Select(selector string) []MemberStatus
+
+	/*
+	   SignalSelect sends a signal to the running members whose labels match the
+	   selector, as for Select, and returns how many were signaled. A nil signal
+	   is replaced as it is by SignalMember. Their exits are handled like any
+	   other exit. A member which has already exited is not signaled.
+	*/
+	SignalSelect(selector string, signal os.Signal) int
+
+	/*
+	   Drain sends the group's DrainSignal to every running member, without
+	   closing the group, as if the group had received the drain signal. The
+	   group keeps running until it is signaled to shut down. Drain does nothing
+	   if the group has no DrainSignal, or has exited.
+	*/
+	Drain()
+}
+
+/*
+MemberStatus describes a running member of a dynamic group, as returned by
+DynamicClient.Select.
+*/
+type MemberStatus struct {
+	Member  Member
+	Process ifrit.Process
+	State   ifrit.ProcessState
+}
+
+/*
+GroupStats counts the members of a dynamic group.  Running is the number of
+members which have started and not yet exited.  Members rejected at insert time
+are not counted.
+*/
+type GroupStats struct {
+	Running         int
+	Started         int
+	ExitedCleanly   int
+	ExitedWithError int
+}
+
+type groupStats struct {
+	stats GroupStats
+	lock  *sync.Mutex
+}
+
+func (s *groupStats) started() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats.Started++
+	s.stats.Running++
+}
+
+func (s *groupStats) exited(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.stats.Running--
+	if err != nil {
+		s.stats.ExitedWithError++
+	} else {
+		s.stats.ExitedCleanly++
+	}
+}
+
+func (s *groupStats) snapshot() GroupStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stats
+}
+
+/*
+ErrMemberNotFound is returned when a member is requested by name, but is not
+present in the group.
+*/
+type ErrMemberNotFound struct {
+	Name string
+}
+
+func (e ErrMemberNotFound) Error() string {
+	return fmt.Sprintf("Member not found: %s", e.Name)
+}
+
+/*
+ErrCountNotReached is returned by WaitForCount when the group exits before the
+number of running members reaches the target.
+*/
+type ErrCountNotReached struct {
+	Target int
+	Count  int
+}
+
+func (e ErrCountNotReached) Error() string {
+	return fmt.Sprintf("Group exited with %d running members, waiting for %d", e.Count, e.Target)
 }
 
 type memberRequest struct {
@@ -57,28 +327,100 @@ type memberRequest struct {
 	Response chan ifrit.Process
 }
 
+type uptimeRequest struct {
+	Name     string
+	Response chan time.Duration
+}
+
+type selectRequest struct {
+	Selector labelSelector
+	Response chan []MemberStatus
+}
+
+type signalSelectRequest struct {
+	Selector labelSelector
+	Signal   os.Signal
+	Response chan int
+}
+
+type pendingCountRequest struct {
+	Response chan int
+}
+
+type insertAllRequest struct {
+	Members  Members
+	Response chan error
+}
+
+type replaceRequest struct {
+	OldName  string
+	Member   Member
+	Response chan error
+}
+
+type signalRequest struct {
+	Name     string
+	Signal   os.Signal
+	Response chan error
+}
+
 /*
 dynamicClient implements DynamicClient.
 */
 type dynamicClient struct {
 	insertChannel       chan Member
 	getMemberChannel    chan memberRequest
+	signalMemberChannel chan signalRequest
+	uptimeChannel       chan uptimeRequest
+	terminationChannel  chan os.Signal
+	pauseChannel        chan bool
+	expectChannel       chan int
+	pendingChannel      chan pendingCountRequest
+	offered             *int64
+	selectChannel       chan selectRequest
+	signalSelectChannel chan signalSelectRequest
+	insertAllChannel    chan insertAllRequest
+	replaceChannel      chan replaceRequest
 	completeNotifier    chan struct{}
 	closeNotifier       chan struct{}
 	closeOnce           *sync.Once
+	completeOnce        *sync.Once
+	signaledNotifier    chan struct{}
+	signaledOnce        *sync.Once
+	stats               *groupStats
 	entranceBroadcaster *entranceEventBroadcaster
 	exitBroadcaster     *exitEventBroadcaster
+	countBroadcaster    *countBroadcaster
+	readyBroadcaster    *groupReadyBroadcaster
+	drainSignal         os.Signal
 }
 
 func newClient(bufferSize int) dynamicClient {
 	return dynamicClient{
 		insertChannel:       make(chan Member),
 		getMemberChannel:    make(chan memberRequest),
+		signalMemberChannel: make(chan signalRequest),
+		uptimeChannel:       make(chan uptimeRequest),
+		terminationChannel:  make(chan os.Signal),
+		pauseChannel:        make(chan bool),
+		expectChannel:       make(chan int),
+		pendingChannel:      make(chan pendingCountRequest),
+		offered:             new(int64),
+		selectChannel:       make(chan selectRequest),
+		signalSelectChannel: make(chan signalSelectRequest),
+		insertAllChannel:    make(chan insertAllRequest),
+		replaceChannel:      make(chan replaceRequest),
 		completeNotifier:    make(chan struct{}),
 		closeNotifier:       make(chan struct{}),
 		closeOnce:           new(sync.Once),
+		completeOnce:        new(sync.Once),
+		signaledNotifier:    make(chan struct{}),
+		signaledOnce:        new(sync.Once),
+		stats:               &groupStats{lock: new(sync.Mutex)},
 		entranceBroadcaster: newEntranceEventBroadcaster(bufferSize),
 		exitBroadcaster:     newExitEventBroadcaster(bufferSize),
+		countBroadcaster:    newCountBroadcaster(),
+		readyBroadcaster:    newGroupReadyBroadcaster(),
 	}
 }
 
@@ -103,6 +445,175 @@ func (c dynamicClient) memberRequests() chan memberRequest {
 	return c.getMemberChannel
 }
 
+func (c dynamicClient) SignalMember(name string, signal os.Signal) error {
+	req := signalRequest{
+		Name:     name,
+		Signal:   signal,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.signalMemberChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrMemberNotFound{name}
+	}
+}
+
+func (c dynamicClient) StopMember(name string) error {
+	return c.SignalMember(name, nil)
+}
+
+func (c dynamicClient) signalRequests() chan signalRequest {
+	return c.signalMemberChannel
+}
+
+func (c dynamicClient) Uptime(name string) (time.Duration, bool) {
+	req := uptimeRequest{
+		Name:     name,
+		Response: make(chan time.Duration),
+	}
+	select {
+	case c.uptimeChannel <- req:
+		uptime, ok := <-req.Response
+		return uptime, ok
+	case <-c.completeNotifier:
+		return 0, false
+	}
+}
+
+func (c dynamicClient) WaitMember(name string) (error, bool) {
+	// Closing done releases any broadcast blocked on the listener, so that it
+	// can be detached on return.
+	done := make(chan struct{})
+	exits := c.exitBroadcaster.attach(func(event ExitEvent) bool {
+		return event.Member.Name == name
+	}, OverflowBlock, done)
+	defer func() {
+		close(done)
+		c.exitBroadcaster.Detach(exits)
+	}()
+
+	select {
+	case exit := <-exits:
+		return exit.Err, true
+	default:
+	}
+
+	// The group removes a member and broadcasts its exit in a single step, so
+	// once the member is reported as absent, any exit has already been sent.
+	if _, ok := c.Get(name); !ok {
+		select {
+		case exit, ok := <-exits:
+			return exit.Err, ok
+		default:
+			return nil, false
+		}
+	}
+
+	exit, ok := <-exits
+	return exit.Err, ok
+}
+
+func (c dynamicClient) PendingCount() int {
+	req := pendingCountRequest{
+		Response: make(chan int, 1),
+	}
+	select {
+	case c.pendingChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return 0
+	}
+}
+
+func (c dynamicClient) Select(selector string) []MemberStatus {
+	req := selectRequest{
+		Selector: parseLabelSelector(selector),
+		Response: make(chan []MemberStatus, 1),
+	}
+	select {
+	case c.selectChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return nil
+	}
+}
+
+func (c dynamicClient) selectRequests() chan selectRequest {
+	return c.selectChannel
+}
+
+func (c dynamicClient) SignalSelect(selector string, signal os.Signal) int {
+	req := signalSelectRequest{
+		Selector: parseLabelSelector(selector),
+		Signal:   signal,
+		Response: make(chan int, 1),
+	}
+	select {
+	case c.signalSelectChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return 0
+	}
+}
+
+func (c dynamicClient) signalSelectRequests() chan signalSelectRequest {
+	return c.signalSelectChannel
+}
+
+func (c dynamicClient) Drain() {
+	if c.drainSignal == nil {
+		return
+	}
+	c.SignalSelect("", c.drainSignal)
+}
+
+func (c dynamicClient) offer(n int) {
+	atomic.AddInt64(c.offered, int64(n))
+}
+
+func (c dynamicClient) offeredCount() int {
+	return int(atomic.LoadInt64(c.offered))
+}
+
+func (c dynamicClient) pendingCountRequests() chan pendingCountRequest {
+	return c.pendingChannel
+}
+
+func (c dynamicClient) uptimeRequests() chan uptimeRequest {
+	return c.uptimeChannel
+}
+
+func (c dynamicClient) SetTerminationSignal(signal os.Signal) {
+	select {
+	case c.terminationChannel <- signal:
+	case <-c.completeNotifier:
+	}
+}
+
+func (c dynamicClient) terminationSignals() chan os.Signal {
+	return c.terminationChannel
+}
+
+func (c dynamicClient) Pause() {
+	c.setPaused(true)
+}
+
+func (c dynamicClient) Resume() {
+	c.setPaused(false)
+}
+
+func (c dynamicClient) setPaused(paused bool) {
+	select {
+	case c.pauseChannel <- paused:
+	case <-c.completeNotifier:
+	}
+}
+
+func (c dynamicClient) pauseRequests() chan bool {
+	return c.pauseChannel
+}
+
 func (c dynamicClient) Inserter() chan<- Member {
 	return c.insertChannel
 }
@@ -111,10 +622,116 @@ func (c dynamicClient) insertEventListener() <-chan Member {
 	return c.insertChannel
 }
 
+func (c dynamicClient) InsertAll(members Members) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	req := insertAllRequest{
+		Members:  members,
+		Response: make(chan error, 1),
+	}
+
+	// The members are counted as offered until the group accepts the request,
+	// at which point the group stops counting them as offered.
+	c.offer(len(members))
+	select {
+	case c.insertAllChannel <- req:
+		return <-req.Response
+	case <-c.closeNotifier:
+		c.offer(-len(members))
+		return ErrMemberRejected{Reason: RejectedGroupClosed}
+	}
+}
+
+func (c dynamicClient) insertAllRequests() chan insertAllRequest {
+	return c.insertAllChannel
+}
+
+func (c dynamicClient) Replace(oldName string, newMember Member) error {
+	err := Members{newMember}.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Closing done releases any broadcast blocked on the listener, so that it
+	// can be detached on return.
+	done := make(chan struct{})
+	entrances := c.entranceBroadcaster.attach(entrancesOf([]string{newMember.Name}), OverflowBlock, done)
+	defer func() {
+		close(done)
+		c.entranceBroadcaster.Detach(entrances)
+	}()
+
+	req := replaceRequest{
+		OldName:  oldName,
+		Member:   newMember,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.replaceChannel <- req:
+		err = <-req.Response
+	case <-c.closeNotifier:
+		err = ErrMemberRejected{Reason: RejectedGroupClosed}
+	}
+	if err != nil {
+		return err
+	}
+
+	for entrance := range entrances {
+		if entrance.Replayed {
+			continue
+		}
+
+		select {
+		case <-entrance.Process.Ready():
+			err := c.StopMember(oldName)
+			if _, ok := err.(ErrMemberNotFound); ok {
+				return nil
+			}
+			return err
+		default:
+		}
+
+		err, _ := c.WaitMember(newMember.Name)
+		if _, ok := err.(StartupError); ok {
+			return err
+		}
+		return StartupError{Member: newMember, Err: err}
+	}
+
+	return StartupError{Member: newMember}
+}
+
+func (c dynamicClient) Stats() GroupStats {
+	return c.stats.snapshot()
+}
+
+func (c dynamicClient) replaceRequests() chan replaceRequest {
+	return c.replaceChannel
+}
+
 func (c dynamicClient) EntranceListener() <-chan EntranceEvent {
 	return c.entranceBroadcaster.Attach()
 }
 
+func (c dynamicClient) EntranceListenerFor(names ...string) <-chan EntranceEvent {
+	return c.entranceBroadcaster.AttachFiltered(entrancesOf(names))
+}
+
+// entrancesOf filters the entrances of the named members.
+func entrancesOf(names []string) func(EntranceEvent) bool {
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+
+	return func(event EntranceEvent) bool {
+		_, ok := wanted[event.Member.Name]
+		return ok
+	}
+}
+
 func (c dynamicClient) broadcastEntrance(event EntranceEvent) {
 	c.entranceBroadcaster.Broadcast(event)
 }
@@ -127,6 +744,78 @@ func (c dynamicClient) ExitListener() <-chan ExitEvent {
 	return c.exitBroadcaster.Attach()
 }
 
+func (c dynamicClient) EntranceListenerWithPolicy(policy OverflowPolicy) <-chan EntranceEvent {
+	return c.entranceBroadcaster.AttachWithPolicy(func(EntranceEvent) bool { return true }, policy)
+}
+
+func (c dynamicClient) ExitListenerWithPolicy(policy OverflowPolicy) <-chan ExitEvent {
+	return c.exitBroadcaster.AttachWithPolicy(func(ExitEvent) bool { return true }, policy)
+}
+
+func (c dynamicClient) EntranceListenerCtx(ctx context.Context) <-chan EntranceEvent {
+	return c.entranceBroadcaster.AttachUntil(ctx.Done())
+}
+
+func (c dynamicClient) ExitListenerCtx(ctx context.Context) <-chan ExitEvent {
+	return c.exitBroadcaster.AttachUntil(ctx.Done())
+}
+
+func (c dynamicClient) DetachEntranceListener(listener <-chan EntranceEvent) {
+	c.entranceBroadcaster.Detach(listener)
+}
+
+func (c dynamicClient) DetachExitListener(listener <-chan ExitEvent) {
+	c.exitBroadcaster.Detach(listener)
+}
+
+func (c dynamicClient) CountListener() <-chan int {
+	return c.countBroadcaster.Attach()
+}
+
+func (c dynamicClient) WaitForCount(ctx context.Context, n int) error {
+	counts := c.countBroadcaster.Attach()
+	defer c.countBroadcaster.Detach(counts)
+
+	count := 0
+	for {
+		select {
+		case latest, ok := <-counts:
+			if !ok {
+				return ErrCountNotReached{Target: n, Count: count}
+			}
+			count = latest
+			if count >= n {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c dynamicClient) broadcastCount(count int) {
+	c.countBroadcaster.Broadcast(count)
+}
+
+func (c dynamicClient) ExpectMembers(n int) {
+	select {
+	case c.expectChannel <- n:
+	case <-c.completeNotifier:
+	}
+}
+
+func (c dynamicClient) expectRequests() chan int {
+	return c.expectChannel
+}
+
+func (c dynamicClient) GroupReadyListener() <-chan GroupReadyEvent {
+	return c.readyBroadcaster.Attach()
+}
+
+func (c dynamicClient) broadcastGroupReady(event GroupReadyEvent) {
+	c.readyBroadcaster.Broadcast(event)
+}
+
 func (c dynamicClient) broadcastExit(event ExitEvent) {
 	c.exitBroadcaster.Broadcast(event)
 }
@@ -138,7 +827,11 @@ func (c dynamicClient) closeExitBroadcaster() {
 func (c dynamicClient) closeBroadcasters() error {
 	c.entranceBroadcaster.Close()
 	c.exitBroadcaster.Close()
-	close(c.completeNotifier)
+	c.countBroadcaster.Close()
+	c.readyBroadcaster.Close()
+	c.completeOnce.Do(func() {
+		close(c.completeNotifier)
+	})
 	return nil
 }
 
@@ -148,6 +841,30 @@ func (c dynamicClient) Close() {
 	})
 }
 
+func (c dynamicClient) Closed() bool {
+	select {
+	case <-c.closeNotifier:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c dynamicClient) Signaled() bool {
+	select {
+	case <-c.signaledNotifier:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c dynamicClient) markSignaled() {
+	c.signaledOnce.Do(func() {
+		close(c.signaledNotifier)
+	})
+}
+
 func (c dynamicClient) CloseNotifier() <-chan struct{} {
 	return c.closeNotifier
 }
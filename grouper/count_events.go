@@ -0,0 +1,84 @@
+package grouper
+
+import "sync"
+
+/*
+countBroadcaster conflates changes to the running member count: each listener
+holds at most the latest count, which replaces any count it has not yet read.
+*/
+type countBroadcaster struct {
+	channels []chan int
+	count    int
+	closed   bool
+	lock     *sync.Mutex
+}
+
+func newCountBroadcaster() *countBroadcaster {
+	return &countBroadcaster{
+		channels: make([]chan int, 0),
+		lock:     new(sync.Mutex),
+	}
+}
+
+func (b *countBroadcaster) Attach() <-chan int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	channel := make(chan int, 1)
+	channel <- b.count
+	if b.closed {
+		close(channel)
+	} else {
+		b.channels = append(b.channels, channel)
+	}
+	return channel
+}
+
+func (b *countBroadcaster) Detach(channel <-chan int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for i := range b.channels {
+		if b.channels[i] != channel {
+			continue
+		}
+		close(b.channels[i])
+		b.channels = append(b.channels[:i], b.channels[i+1:]...)
+		return
+	}
+}
+
+func (b *countBroadcaster) Broadcast(count int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.count = count
+	for _, channel := range b.channels {
+		// Only the broadcaster sends, so once a stale count is discarded
+		// there is room for the new one.
+		select {
+		case <-channel:
+		default:
+		}
+		channel <- count
+	}
+}
+
+func (b *countBroadcaster) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, channel := range b.channels {
+		close(channel)
+	}
+	b.channels = nil
+	b.closed = true
+}
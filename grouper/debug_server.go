@@ -0,0 +1,66 @@
+package grouper
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewDebugServer serves the state of a dynamic group as JSON on addr, as an
+ifrit.HTTPServer, which may be run alongside the group or as a member of it.
+
+GET /members lists the running members, ordered by name, with their state,
+labels, and how many seconds they have been ready.  GET /stats returns the
+group's GroupStats.  Once the group has exited, /members is empty, and /stats
+reports the final counts.
+*/
+func NewDebugServer(addr string, client DynamicClient) ifrit.Runner {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/members", func(w http.ResponseWriter, r *http.Request) {
+		members := []debugMember{}
+		for _, status := range client.Select("") {
+			member := debugMember{
+				Name:   status.Member.Name,
+				State:  status.State.String(),
+				Labels: status.Member.Labels(),
+			}
+			if uptime, ok := client.Uptime(status.Member.Name); ok {
+				member.UptimeSeconds = uptime.Seconds()
+			}
+			members = append(members, member)
+		}
+		writeDebugJSON(w, members)
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		stats := client.Stats()
+		writeDebugJSON(w, debugStats{
+			Running:         stats.Running,
+			Started:         stats.Started,
+			ExitedCleanly:   stats.ExitedCleanly,
+			ExitedWithError: stats.ExitedWithError,
+		})
+	})
+
+	return ifrit.NewHTTPServer(addr, mux)
+}
+
+type debugMember struct {
+	Name          string            `json:"name"`
+	State         string            `json:"state"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	UptimeSeconds float64           `json:"uptime_seconds"`
+}
+
+type debugStats struct {
+	Running         int `json:"running"`
+	Started         int `json:"started"`
+	ExitedCleanly   int `json:"exited_cleanly"`
+	ExitedWithError int `json:"exited_with_error"`
+}
+
+func writeDebugJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,100 @@
+package grouper_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DebugServer", func() {
+	var (
+		addr          string
+		childRunner1  *fake_runner.TestRunner
+		childRunner2  *fake_runner.TestRunner
+		poolProcess   ifrit.Process
+		serverProcess ifrit.Process
+	)
+
+	getJSON := func(path string, v interface{}) {
+		resp, err := http.Get("http://" + addr + path)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		Ω(resp.Header.Get("Content-Type")).Should(Equal("application/json"))
+		Ω(json.NewDecoder(resp.Body).Decode(v)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).ShouldNot(HaveOccurred())
+		addr = listener.Addr().String()
+		Ω(listener.Close()).Should(Succeed())
+
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+
+		pool := grouper.NewDynamic(nil, 2, 2)
+		client := pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+
+		Ω(client.InsertAll(grouper.Members{
+			{Name: "web", Runner: grouper.Labeled(childRunner1, map[string]string{"tier": "frontend"})},
+			{Name: "worker", Runner: childRunner2},
+		})).Should(Succeed())
+		childRunner1.TriggerReady()
+		childRunner2.WaitForCall()
+		Eventually(func() bool {
+			_, ok := client.Uptime("web")
+			return ok
+		}).Should(BeTrue())
+
+		serverProcess = ifrit.Invoke(grouper.NewDebugServer(addr, client))
+		Ω(serverProcess.Ready()).Should(BeClosed())
+	})
+
+	AfterEach(func() {
+		serverProcess.Signal(os.Interrupt)
+		Eventually(serverProcess.Wait()).Should(Receive(BeNil()))
+
+		poolProcess.Signal(os.Kill)
+		Eventually(func() ifrit.ProcessState {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			return poolProcess.State()
+		}).Should(Equal(ifrit.StateExited))
+	})
+
+	It("serves the running members", func() {
+		var members []map[string]interface{}
+		getJSON("/members", &members)
+
+		Ω(members).Should(HaveLen(2))
+		Ω(members[0]).Should(HaveKeyWithValue("name", "web"))
+		Ω(members[0]).Should(HaveKeyWithValue("state", "ready"))
+		Ω(members[0]).Should(HaveKeyWithValue("labels", map[string]interface{}{"tier": "frontend"}))
+		Ω(members[0]).Should(HaveKey("uptime_seconds"))
+		Ω(members[1]).Should(HaveKeyWithValue("name", "worker"))
+		Ω(members[1]).Should(HaveKeyWithValue("state", "starting"))
+		Ω(members[1]).Should(HaveKeyWithValue("uptime_seconds", BeZero()))
+	})
+
+	It("serves the group's stats", func() {
+		var stats map[string]int
+		getJSON("/stats", &stats)
+
+		Ω(stats).Should(Equal(map[string]int{
+			"running":           2,
+			"started":           2,
+			"exited_cleanly":    0,
+			"exited_with_error": 0,
+		}))
+	})
+})
@@ -0,0 +1,260 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A DependentMember is a Member which may only be started once all of the
+members named in DependsOn are ready.
+*/
+type DependentMember struct {
+	Member
+	DependsOn []string
+}
+
+/*
+NewDependencyGroup starts each member as soon as all of its dependencies are
+ready, so members without a dependency between them start in parallel.  On
+shutdown, it will shut the started processes down in reverse topological order.
+Use a dependency group to describe processes whose dependencies form a graph
+rather than a list.
+
+Members are validated before anything is started: unknown dependencies and
+dependency cycles are returned as errors from Run.
+*/
+func NewDependencyGroup(terminationSignal os.Signal, members []DependentMember) ifrit.Runner {
+	return &dependencyGroup{
+		terminationSignal: terminationSignal,
+		members:           members,
+	}
+}
+
+type dependencyGroup struct {
+	terminationSignal os.Signal
+	members           []DependentMember
+	order             []DependentMember
+
+	client  DynamicClient
+	started map[string]struct{}
+}
+
+// GroupConfig reports the group's termination signal.
+func (g *dependencyGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal}
+}
+
+func (g *dependencyGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := g.validate()
+	if err != nil {
+		return err
+	}
+
+	pool := NewDynamic(nil, len(g.members), len(g.members))
+	g.client = pool.Client()
+	g.started = make(map[string]struct{})
+
+	poolProcess := ifrit.Background(pool)
+	entrances := g.client.EntranceListener()
+	exits := g.client.ExitListener()
+
+	signal, errTrace := g.dependencyStart(signals, entrances, exits)
+	if errTrace != nil {
+		return g.stop(g.terminationSignal, errTrace, exits, poolProcess)
+	}
+
+	if signal != nil {
+		return g.stop(signal, errTrace, exits, poolProcess)
+	}
+
+	close(ready)
+
+	select {
+	case signal = <-signals:
+	case exit := <-exits:
+		signal = g.terminationSignal
+		errTrace = append(errTrace, exit)
+	}
+
+	return g.stop(signal, errTrace, exits, poolProcess)
+}
+
+func (g *dependencyGroup) validate() error {
+	members := make(Members, 0, len(g.members))
+	for _, member := range g.members {
+		members = append(members, member.Member)
+	}
+
+	err := members.Validate()
+	if err != nil {
+		return err
+	}
+
+	order, err := topologicalOrder(g.members)
+	if err != nil {
+		return err
+	}
+
+	g.order = order
+	return nil
+}
+
+func (g *dependencyGroup) dependencyStart(
+	signals <-chan os.Signal,
+	entrances <-chan EntranceEvent,
+	exits <-chan ExitEvent,
+) (os.Signal, ErrorTrace) {
+	insert := g.client.Inserter()
+
+	waitingOn := make(map[string]int, len(g.members))
+	dependents := make(map[string][]DependentMember, len(g.members))
+	for _, member := range g.members {
+		waitingOn[member.Name] = len(member.DependsOn)
+		for _, dependency := range member.DependsOn {
+			dependents[dependency] = append(dependents[dependency], member)
+		}
+	}
+
+	for _, member := range g.members {
+		if waitingOn[member.Name] == 0 {
+			g.start(member, insert)
+		}
+	}
+
+	numReady := 0
+	for numReady < len(g.members) {
+		select {
+		case signal := <-signals:
+			return signal, nil
+
+		case exit := <-exits:
+			return nil, ErrorTrace{exit}
+
+		case entrance := <-entrances:
+			select {
+			case <-entrance.Process.Ready():
+			default:
+				// the member exited before becoming ready; its exit event follows.
+				continue
+			}
+
+			numReady++
+			for _, dependent := range dependents[entrance.Member.Name] {
+				waitingOn[dependent.Name]--
+				if waitingOn[dependent.Name] == 0 {
+					g.start(dependent, insert)
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (g *dependencyGroup) start(member DependentMember, insert chan<- Member) {
+	insert <- member.Member
+	g.started[member.Name] = struct{}{}
+}
+
+func (g *dependencyGroup) stop(
+	signal os.Signal,
+	errTrace ErrorTrace,
+	exits <-chan ExitEvent,
+	poolProcess ifrit.Process,
+) error {
+	started := Members{}
+	for i := len(g.order) - 1; i >= 0; i-- {
+		if _, ok := g.started[g.order[i].Name]; ok {
+			started = append(started, g.order[i].Member)
+		}
+	}
+
+	return stopPool(g.client, poolProcess, exits, started, signal, true, errTrace)
+}
+
+func topologicalOrder(members []DependentMember) ([]DependentMember, error) {
+	byName := make(map[string]DependentMember, len(members))
+	for _, member := range members {
+		byName[member.Name] = member
+	}
+
+	unknown := ErrUnknownDependencies{}
+	for _, member := range members {
+		for _, dependency := range member.DependsOn {
+			if _, ok := byName[dependency]; !ok {
+				unknown.Dependencies = append(unknown.Dependencies, fmt.Sprintf("%s -> %s", member.Name, dependency))
+			}
+		}
+	}
+	if len(unknown.Dependencies) > 0 {
+		return nil, unknown
+	}
+
+	placed := make(map[string]struct{}, len(members))
+	order := make([]DependentMember, 0, len(members))
+
+	for len(order) < len(members) {
+		progress := false
+		for _, member := range members {
+			if _, ok := placed[member.Name]; ok {
+				continue
+			}
+			if !dependenciesPlaced(member, placed) {
+				continue
+			}
+			placed[member.Name] = struct{}{}
+			order = append(order, member)
+			progress = true
+		}
+
+		if !progress {
+			cycle := ErrDependencyCycle{}
+			for _, member := range members {
+				if _, ok := placed[member.Name]; !ok {
+					cycle.Names = append(cycle.Names, member.Name)
+				}
+			}
+			return nil, cycle
+		}
+	}
+
+	return order, nil
+}
+
+func dependenciesPlaced(member DependentMember, placed map[string]struct{}) bool {
+	for _, dependency := range member.DependsOn {
+		if _, ok := placed[dependency]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+ErrDependencyCycle is returned when the members of a dependency group can not
+be ordered. It contains the names of every member which is part of, or depends
+upon, a cycle.
+*/
+type ErrDependencyCycle struct {
+	Names []string
+}
+
+func (e ErrDependencyCycle) Error() string {
+	return fmt.Sprintf("Dependency cycle between members: %s", strings.Join(e.Names, ", "))
+}
+
+/*
+ErrUnknownDependencies is returned when a member of a dependency group depends
+upon a name which is not a member of the group.
+*/
+type ErrUnknownDependencies struct {
+	Dependencies []string
+}
+
+func (e ErrUnknownDependencies) Error() string {
+	return fmt.Sprintf("Unknown member dependencies: %s", strings.Join(e.Dependencies, ", "))
+}
@@ -0,0 +1,174 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dependency Group", func() {
+	var (
+		groupRunner  ifrit.Runner
+		groupProcess ifrit.Process
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	Describe("a diamond graph", func() {
+		var (
+			runnerA *fake_runner.TestRunner
+			runnerB *fake_runner.TestRunner
+			runnerC *fake_runner.TestRunner
+			runnerD *fake_runner.TestRunner
+		)
+
+		BeforeEach(func() {
+			runnerA = fake_runner.NewTestRunner()
+			runnerB = fake_runner.NewTestRunner()
+			runnerC = fake_runner.NewTestRunner()
+			runnerD = fake_runner.NewTestRunner()
+
+			groupRunner = grouper.NewDependencyGroup(os.Interrupt, []grouper.DependentMember{
+				{grouper.Member{"d", runnerD}, []string{"b", "c"}},
+				{grouper.Member{"b", runnerB}, []string{"a"}},
+				{grouper.Member{"c", runnerC}, []string{"a"}},
+				{grouper.Member{"a", runnerA}, nil},
+			})
+
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		AfterEach(func() {
+			runnerA.EnsureExit()
+			runnerB.EnsureExit()
+			runnerC.EnsureExit()
+			runnerD.EnsureExit()
+
+			groupProcess.Signal(os.Kill)
+			Eventually(groupProcess.Wait()).Should(Receive())
+		})
+
+		It("starts members once their dependencies are ready", func() {
+			Eventually(runnerA.RunCallCount).Should(Equal(1))
+			Consistently(runnerB.RunCallCount, Δ).Should(BeZero())
+			Consistently(runnerC.RunCallCount, Δ).Should(BeZero())
+
+			runnerA.TriggerReady()
+
+			Eventually(runnerB.RunCallCount).Should(Equal(1))
+			Eventually(runnerC.RunCallCount).Should(Equal(1))
+			Consistently(runnerD.RunCallCount, Δ).Should(BeZero())
+
+			runnerB.TriggerReady()
+			Consistently(runnerD.RunCallCount, Δ).Should(BeZero())
+
+			runnerC.TriggerReady()
+			Eventually(runnerD.RunCallCount).Should(Equal(1))
+			Consistently(groupProcess.Ready(), Δ).ShouldNot(BeClosed())
+
+			runnerD.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		Describe("when all the members are ready", func() {
+			var signalA, signalB, signalC, signalD <-chan os.Signal
+
+			BeforeEach(func() {
+				signalA = runnerA.WaitForCall()
+				runnerA.TriggerReady()
+				signalB = runnerB.WaitForCall()
+				runnerB.TriggerReady()
+				signalC = runnerC.WaitForCall()
+				runnerC.TriggerReady()
+				signalD = runnerD.WaitForCall()
+				runnerD.TriggerReady()
+
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+				groupProcess.Signal(os.Interrupt)
+			})
+
+			It("stops the members in reverse topological order", func() {
+				Eventually(signalD).Should(Receive(Equal(os.Interrupt)))
+				Consistently(signalA, Δ).ShouldNot(Receive())
+				runnerD.TriggerExit(nil)
+
+				Eventually(signalC).Should(Receive(Equal(os.Interrupt)))
+				runnerC.TriggerExit(nil)
+				Eventually(signalB).Should(Receive(Equal(os.Interrupt)))
+				Consistently(signalA, Δ).ShouldNot(Receive())
+				runnerB.TriggerExit(nil)
+
+				Eventually(signalA).Should(Receive(Equal(os.Interrupt)))
+				runnerA.TriggerExit(nil)
+
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
+		Describe("when a member fails to start", func() {
+			BeforeEach(func() {
+				signalA := runnerA.WaitForCall()
+				runnerA.TriggerReady()
+				runnerB.WaitForCall()
+				signalC := runnerC.WaitForCall()
+				runnerB.TriggerExit(errors.New("Fail"))
+
+				Eventually(signalC).Should(Receive(Equal(os.Interrupt)))
+				runnerC.TriggerExit(nil)
+
+				Eventually(signalA).Should(Receive(Equal(os.Interrupt)))
+				runnerA.TriggerExit(nil)
+			})
+
+			It("does not start its dependents, and returns the error", func() {
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(runnerD.RunCallCount()).Should(BeZero())
+
+				errTrace := err.(grouper.ErrorTrace)
+				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{Member: grouper.Member{Name: "b", Runner: runnerB}, Err: grouper.StartupError{
+					Member: grouper.Member{Name: "b", Runner: runnerB},
+					Err:    errors.New("Fail"),
+				}}))
+			})
+		})
+	})
+
+	Describe("Validate", func() {
+		It("returns an error without starting anything when there is a cycle", func() {
+			runnerA := fake_runner.NewTestRunner()
+			runnerB := fake_runner.NewTestRunner()
+			runnerC := fake_runner.NewTestRunner()
+
+			groupRunner = grouper.NewDependencyGroup(os.Interrupt, []grouper.DependentMember{
+				{grouper.Member{"a", runnerA}, nil},
+				{grouper.Member{"b", runnerB}, []string{"a", "c"}},
+				{grouper.Member{"c", runnerC}, []string{"b"}},
+			})
+
+			groupProcess = ifrit.Background(groupRunner)
+
+			Eventually(groupProcess.Wait()).Should(Receive(Equal(grouper.ErrDependencyCycle{[]string{"b", "c"}})))
+			Ω(runnerA.RunCallCount()).Should(BeZero())
+			Ω(runnerB.RunCallCount()).Should(BeZero())
+			Ω(runnerC.RunCallCount()).Should(BeZero())
+		})
+
+		It("returns an error when a dependency is not a member", func() {
+			groupRunner = grouper.NewDependencyGroup(os.Interrupt, []grouper.DependentMember{
+				{grouper.Member{"a", fake_runner.NewTestRunner()}, []string{"missing"}},
+			})
+
+			groupProcess = ifrit.Background(groupRunner)
+
+			Eventually(groupProcess.Wait()).Should(Receive(BeAssignableToTypeOf(grouper.ErrUnknownDependencies{})))
+		})
+	})
+})
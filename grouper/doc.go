@@ -4,12 +4,21 @@ which are then organized into an execution tree.  If you have modeled your subsy
 as ifrit runners, startup and shutdown of your entire application can now
 be controlled.
 
-Grouper provides three strategies for system startup: two static group
+Grouper provides seven strategies for system startup: six static group
 strategies, and one DynamicGroup.  Each static group strategy takes a
 list of members, and starts the members in the following manner:
 
-  - Parallel: all processes are started simultaneously.
-  - Ordered:  the next process is started when the previous is ready.
+  - Parallel:   all processes are started simultaneously, or N at a time.
+  - Ordered:    the next process is started when the previous is ready.
+  - Dependency: each process is started when its dependencies are ready.
+  - Layered:    layers of parallel processes are started in order.
+  - Race:       all processes are started, and the first to be ready is kept.
+  - Staggered:  the next process is started after a fixed delay.
+
+NewStaged creates a layered group whose failed stages are not restarted.
+
+A WorkQueue, built on a DynamicGroup, runs a list of members N at a time, and
+exits once every member has run.
 
 The DynamicGroup allows up to N processes to be run concurrently. The dynamic
 group runs indefinitely until it is closed or signaled. The DynamicGroup provides
@@ -17,7 +26,7 @@ a DynamicClient to allow interacting with the group.  A dynamic group has the
 following properties:
 
   - A dynamic group allows Members to be inserted until it is closed.
-  - A dynamic group can be manually closed via it's client.
+  - A dynamic group can be manually closed via its client.
   - A dynamic group is automatically closed once it is signaled.
   - Once a dynamic group is closed, it acts like a static group.
 
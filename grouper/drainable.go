@@ -0,0 +1,12 @@
+package grouper
+
+/*
+Drainable may be implemented by a member's Runner which continues to finish
+in-flight work after it has exited.  When a dynamic group shuts down, it does
+not consider a Drainable member stopped until the channel returned by Drain is
+closed, bounded by the group's ShutdownDeadline.  Drain is called at most once
+per run.
+*/
+type Drainable interface {
+	Drain() <-chan struct{}
+}
@@ -0,0 +1,90 @@
+package grouper_test
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type drainingRunner struct {
+	drained chan struct{}
+	finish  sync.Once
+}
+
+func newDrainingRunner() *drainingRunner {
+	return &drainingRunner{drained: make(chan struct{})}
+}
+
+func (r *drainingRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+	<-signals
+	return nil
+}
+
+func (r *drainingRunner) Drain() <-chan struct{} {
+	return r.drained
+}
+
+func (r *drainingRunner) Finish() {
+	r.finish.Do(func() {
+		close(r.drained)
+	})
+}
+
+var _ = Describe("Drainable", func() {
+	var (
+		draining    *drainingRunner
+		poolProcess ifrit.Process
+		exits       <-chan grouper.ExitEvent
+	)
+
+	startPool := func(deadline time.Duration) {
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity:      1,
+			EventBufferSize:  1,
+			ShutdownDeadline: deadline,
+		})
+		client := pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+		exits = client.ExitListener()
+
+		client.Inserter() <- grouper.Member{Name: "draining", Runner: draining}
+		Eventually(client.EntranceListener()).Should(Receive())
+	}
+
+	BeforeEach(func() {
+		draining = newDrainingRunner()
+	})
+
+	AfterEach(func() {
+		draining.Finish()
+		Eventually(poolProcess.State).Should(Equal(ifrit.StateExited))
+	})
+
+	It("does not consider the member stopped until it has drained", func() {
+		startPool(0)
+		poolProcess.Signal(os.Interrupt)
+
+		Consistently(exits).ShouldNot(Receive())
+		Consistently(poolProcess.Wait()).ShouldNot(Receive())
+
+		draining.Finish()
+		Eventually(exits).Should(Receive())
+		Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("stops waiting for a slow drain at the shutdown deadline", func() {
+		startPool(100 * time.Millisecond)
+		poolProcess.Signal(os.Interrupt)
+
+		var err error
+		Eventually(poolProcess.Wait()).Should(Receive(&err))
+		Ω(err).Should(Equal(grouper.ErrShutdownDeadlineExceeded{Members: []string{"draining"}}))
+	})
+})
@@ -3,6 +3,10 @@ package grouper
 import (
 	"fmt"
 	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
@@ -17,18 +21,120 @@ members to complete before exiting.
 type DynamicGroup interface {
 	ifrit.Runner
 	Client() DynamicClient
+
+	// GroupConfig reports the group's termination signal, capacity and event
+	// buffer size.
+	GroupConfig() GroupConfigInfo
 }
 
 type dynamicGroup struct {
-	client            dynamicClient
-	terminationSignal os.Signal
-	poolSize          int
+	client              dynamicClient
+	terminationSignal   os.Signal
+	poolSize            int
+	maxStartups         int
+	eventBufferSize     int
+	tracer              Tracer
+	metrics             MetricsHooks
+	stuckThreshold      time.Duration
+	onMemberStuck       func(member Member, waited time.Duration)
+	shutdownDeadline    time.Duration
+	escalationSignal    os.Signal
+	recoverPanics       bool
+	semaphore           *Semaphore
+	rejectWhilePaused   bool
+	collectErrors       bool
+	internalEventBuffer int
+	drainSignal         os.Signal
+	exitMode            ExitMode
+	topology            *topology
+}
+
+/*
+DynamicConfig describes a DynamicGroup.  TerminationSignal, MaxCapacity and
+EventBufferSize have the same meaning as the arguments to NewDynamic.  The
+remaining fields are optional.
+*/
+type DynamicConfig struct {
+	TerminationSignal os.Signal
+	MaxCapacity       int
+	EventBufferSize   int
+
+	// Tracer, if set, is used to open a span around each member's startup and run.
+	Tracer Tracer
+
+	// Metrics hooks are invoked as members start, become ready, and exit.
+	Metrics MetricsHooks
+
+	// OnMemberStuck, if set, is called once for any member which has neither
+	// become ready nor exited within StuckThreshold of being started.  The
+	// member is left running.
+	StuckThreshold time.Duration
+	OnMemberStuck  func(member Member, waited time.Duration)
+
+	// ShutdownDeadline, if set, bounds how long the group waits for its members
+	// to exit once they have been signaled.  Members still running halfway
+	// through the deadline are sent EscalationSignal, which defaults to os.Kill.
+	// If members are still running when the deadline passes, Run returns
+	// ErrShutdownDeadlineExceeded without waiting for them.  The deadline also
+	// bounds the wait for Drainable members to drain.
+	ShutdownDeadline time.Duration
+	EscalationSignal os.Signal
+
+	// RecoverPanics, if set, recovers a panic in a member, which then exits
+	// with an ifrit.PanicError.  By default, a panicking member crashes the
+	// program.
+	RecoverPanics bool
+
+	// Semaphore, if set, is acquired by each member before it runs, limiting
+	// the members running across every group which shares it.
+	Semaphore *Semaphore
+
+	// RejectWhilePaused, if set, rejects members inserted while the group is
+	// paused, rather than blocking the insert until the group is resumed.
+	RejectWhilePaused bool
+
+	// When a member which is not Optional exits with an error, Run returns an
+	// ErrorTrace of that member's exit, once the group has finished; if several
+	// failed, the first to exit is returned.  CollectErrors, if set, causes Run
+	// to return an ErrorTrace of every member's exit instead, as a parallel
+	// group does.  Members which were rejected are not included.
+	CollectErrors bool
+
+	// InternalEventBuffer sets the buffer size of the channels on which each
+	// member's entrance and exit are sent to the group's run loop.  By default
+	// they are unbuffered, and a member's supervising goroutine waits for the
+	// run loop to handle its event, which serializes bursts of members
+	// becoming ready or exiting at once.  A buffer lets those goroutines move
+	// on, at the cost of the run loop falling further behind: events are still
+	// handled, and broadcast, in the order they were received, but the group's
+	// view of which members are running may lag by up to the buffer size.
+	InternalEventBuffer int
+
+	// DrainSignal, if set, is the first phase of a two-phase shutdown.  When
+	// the group receives the drain signal, or DynamicClient.Drain is called,
+	// it is sent to every running member, but the group is neither closed nor
+	// shut down: it keeps running until it receives any other signal, which it
+	// handles as usual.  A member which exits after draining is handled like
+	// any other exit, so it propagates the termination signal, if there is one.
+	DrainSignal os.Signal
+
+	// ExitMode decides which member exits propagate the termination signal.
+	// By default, any exit does.
+	ExitMode ExitMode
+
+	// MaxConcurrentStartups, if set, limits how many members may be starting,
+	// that is neither ready nor exited, at once, independently of MaxCapacity.
+	// Further inserts wait, as they do for capacity, until a starting member
+	// becomes ready or exits.  Members started by Replace are not limited.
+	MaxConcurrentStartups int
 }
 
 /*
 NewDynamic creates a DynamicGroup.
 
-The maxCapacity argument sets the maximum number of concurrent processes.
+The maxCapacity argument sets the maximum number of concurrent processes.  A
+member whose Runner is Weighted counts as that many processes; an insert which
+would exceed the capacity waits until enough running members have exited.
 
 The eventBufferSize argument sets the number of entrance and exit events to be
 retained by the system.  When a new event listener attaches, it will receive
@@ -41,10 +147,46 @@ being signaled, the group propogates the termination signal.  A nil termination
 signal is not propogated.
 */
 func NewDynamic(terminationSignal os.Signal, maxCapacity int, eventBufferSize int) DynamicGroup {
+	return NewDynamicWithConfig(DynamicConfig{
+		TerminationSignal: terminationSignal,
+		MaxCapacity:       maxCapacity,
+		EventBufferSize:   eventBufferSize,
+	})
+}
+
+/*
+NewDynamicWithConfig creates a DynamicGroup from a DynamicConfig, allowing the
+optional settings to be configured.
+*/
+func NewDynamicWithConfig(config DynamicConfig) DynamicGroup {
+	escalationSignal := config.EscalationSignal
+	if escalationSignal == nil {
+		escalationSignal = os.Kill
+	}
+
+	client := newClient(config.EventBufferSize)
+	client.drainSignal = config.DrainSignal
+
 	return &dynamicGroup{
-		client:            newClient(eventBufferSize),
-		poolSize:          maxCapacity,
-		terminationSignal: terminationSignal,
+		client:              client,
+		poolSize:            config.MaxCapacity,
+		maxStartups:         config.MaxConcurrentStartups,
+		eventBufferSize:     config.EventBufferSize,
+		terminationSignal:   config.TerminationSignal,
+		tracer:              config.Tracer,
+		metrics:             config.Metrics,
+		stuckThreshold:      config.StuckThreshold,
+		onMemberStuck:       config.OnMemberStuck,
+		shutdownDeadline:    config.ShutdownDeadline,
+		escalationSignal:    escalationSignal,
+		recoverPanics:       config.RecoverPanics,
+		semaphore:           config.Semaphore,
+		rejectWhilePaused:   config.RejectWhilePaused,
+		collectErrors:       config.CollectErrors,
+		internalEventBuffer: config.InternalEventBuffer,
+		drainSignal:         config.DrainSignal,
+		exitMode:            config.ExitMode,
+		topology:            newTopology(),
 	}
 }
 
@@ -52,30 +194,212 @@ func (p *dynamicGroup) Client() DynamicClient {
 	return p.client
 }
 
+// Tree describes the group and its running members, in order of name.
+func (p *dynamicGroup) Tree() TopologyNode {
+	node := p.topology.tree(NodeDynamic)
+	for _, status := range p.client.Select("") {
+		node.Children = append(node.Children, memberNode(status.Member, status.Process))
+	}
+	return node
+}
+
+func (p *dynamicGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{
+		TerminationSignal: p.terminationSignal,
+		PoolSize:          p.poolSize,
+		EventBufferSize:   p.eventBufferSize,
+	}
+}
+
 func (p *dynamicGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer p.topology.setState(ifrit.StateExited)
+
 	processes := newProcessSet()
 	insertEvents := p.client.insertEventListener()
 	memberRequests := p.client.memberRequests()
+	signalRequests := p.client.signalRequests()
+	uptimeRequests := p.client.uptimeRequests()
+	terminationSignals := p.client.terminationSignals()
+	pauseRequests := p.client.pauseRequests()
+	expectRequests := p.client.expectRequests()
+	pendingCountRequests := p.client.pendingCountRequests()
+	selectRequests := p.client.selectRequests()
+	signalSelectRequests := p.client.signalSelectRequests()
+	terminationSignal := p.terminationSignal
 	closeNotifier := p.client.CloseNotifier()
-	entranceEvents := make(entranceEventChannel)
-	exitEvents := make(exitEventChannel)
+	entranceEvents := make(entranceEventChannel, p.internalEventBuffer)
+	exitEvents := make(exitEventChannel, p.internalEventBuffer)
+	done := make(chan struct{})
+	defer close(done)
+
+	// stopping is closed once the group begins to shut down, before any member
+	// is signaled, so that Drainable members are awaited.
+	stopping := make(chan struct{})
+
+	var escalate, deadline <-chan time.Time
+	var escalateTimer, deadlineTimer *time.Timer
+	beginShutdown := func() {
+		select {
+		case <-stopping:
+			return
+		default:
+			close(stopping)
+			p.client.markSignaled()
+		}
+
+		if p.shutdownDeadline <= 0 {
+			return
+		}
+		escalateTimer = time.NewTimer(p.shutdownDeadline / 2)
+		deadlineTimer = time.NewTimer(p.shutdownDeadline)
+		escalate = escalateTimer.C
+		deadline = deadlineTimer.C
+	}
+	defer func() {
+		if deadlineTimer != nil {
+			escalateTimer.Stop()
+			deadlineTimer.Stop()
+		}
+	}()
 
 	invoking := 0
+	start := func(member Member) {
+		var runner ifrit.Runner = member
+		if p.recoverPanics {
+			runner = ifrit.RecoverPanics(nil)(runner)
+		}
+		if p.semaphore != nil {
+			runner = p.semaphore.Limit(runner)
+		}
+
+		process := ifrit.Background(runner)
+		processes.Add(member, process)
+		p.client.stats.started()
+		p.client.broadcastCount(processes.Length())
+		invoking++
+
+		go p.waitForEvents(member, process, entranceEvents, exitEvents, stopping, done)
+	}
+
+	// pending holds inserted members, in order, which are waiting for enough
+	// capacity to be released by exiting members, or for starting members to
+	// become ready.  No further inserts are accepted while members are pending.
+	var pending Members
+	startPending := func() {
+		for len(pending) > 0 && processes.Fits(pending[0].weight(), p.poolSize) {
+			if p.maxStartups > 0 && invoking >= p.maxStartups {
+				return
+			}
+			start(pending[0])
+			pending = pending[1:]
+		}
+	}
+
+	reject := func(member Member, reason string) {
+		p.client.broadcastExit(newExitEvent(member, ErrMemberRejected{Reason: reason}))
+	}
+	rejectPending := func() {
+		for _, member := range pending {
+			reject(member, RejectedGroupStopped)
+		}
+		pending = nil
+	}
+
+	// Once expectedMembers have become ready, a GroupReadyEvent is emitted, at
+	// most once.
+	expectedMembers := 0
+	numReady := 0
+	checkGroupReady := func() {
+		if expectedMembers > 0 && numReady >= expectedMembers {
+			p.client.broadcastGroupReady(GroupReadyEvent{Members: numReady})
+		}
+	}
+
+	receiveEntrance := func(entranceEvent EntranceEvent) {
+		invoking--
+		select {
+		case <-entranceEvent.Process.Ready():
+			processes.MarkReady(entranceEvent.Member.Name, time.Now())
+			numReady++
+		default:
+		}
+		p.client.broadcastEntrance(entranceEvent)
+		checkGroupReady()
+
+		if !processes.Signaled() {
+			startPending()
+		}
+
+		if closeNotifier == nil && invoking == 0 && len(pending) == 0 {
+			p.client.closeEntranceBroadcaster()
+			entranceEvents = nil
+		}
+	}
+
+	// While paused, inserts are not received, unless they are to be rejected.
+	// insertEvents continues to track whether the group could otherwise accept
+	// new members.
+	paused := false
+
+	// failure is the first exit of a member which is not Optional with an
+	// error, which Run returns unless the group collects every error.
+	var failure ErrorTrace
+	var errTrace ErrorTrace
+	finish := func() error {
+		err := p.client.closeBroadcasters()
+		if p.collectErrors && errTrace.requiredFailed() {
+			return errTrace
+		}
+		if failure != nil {
+			return failure
+		}
+		return err
+	}
+
 	close(ready)
+	p.topology.setState(ifrit.StateReady)
 
 	for {
+		inserts := insertEvents
+		if paused && !p.rejectWhilePaused {
+			inserts = nil
+		}
+		var insertAllRequests chan insertAllRequest
+		if inserts != nil {
+			insertAllRequests = p.client.insertAllRequests()
+		}
+		var replaceRequests chan replaceRequest
+		if closeNotifier != nil && !processes.Signaled() {
+			replaceRequests = p.client.replaceRequests()
+		}
+
 		select {
 		case shutdown := <-signals:
-			processes.Signal(shutdown)
+			if p.drainSignal != nil && shutdown == p.drainSignal {
+				processes.Forward(shutdown)
+				continue
+			}
+
 			p.client.Close()
+			beginShutdown()
+			processes.Signal(shutdown)
+			rejectPending()
+
+		case <-escalate:
+			escalate = nil
+			processes.Signal(p.escalationSignal)
+
+		case <-deadline:
+			p.client.closeBroadcasters()
+			return ErrShutdownDeadlineExceeded{Members: processes.Names()}
 
 		case <-closeNotifier:
 			closeNotifier = nil
 			insertEvents = nil
 			if processes.Length() == 0 {
-				return p.client.closeBroadcasters()
+				return finish()
 			}
-			if invoking == 0 {
+			if invoking == 0 && len(pending) == 0 {
 				p.client.closeEntranceBroadcaster()
 			}
 
@@ -86,93 +410,320 @@ func (p *dynamicGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 			}
 			close(memberRequest.Response)
 
-		case newMember, ok := <-insertEvents:
+		case signalRequest := <-signalRequests:
+			process, ok := processes.Get(signalRequest.Name)
+			if !ok {
+				signalRequest.Response <- ErrMemberNotFound{signalRequest.Name}
+				break
+			}
+			process.Signal(memberSignal(signalRequest.Signal, terminationSignal))
+			signalRequest.Response <- nil
+
+		case signalSelectRequest := <-signalSelectRequests:
+			signal := memberSignal(signalSelectRequest.Signal, terminationSignal)
+			signaled := 0
+			for _, status := range processes.Select(signalSelectRequest.Selector) {
+				if status.State == ifrit.StateExited {
+					continue
+				}
+				status.Process.Signal(signal)
+				signaled++
+			}
+			signalSelectRequest.Response <- signaled
+
+		case uptimeRequest := <-uptimeRequests:
+			uptime, ok := processes.Uptime(uptimeRequest.Name)
+			if ok {
+				uptimeRequest.Response <- uptime
+			}
+			close(uptimeRequest.Response)
+
+		case selectRequest := <-selectRequests:
+			selectRequest.Response <- processes.Select(selectRequest.Selector)
+
+		case pendingCountRequest := <-pendingCountRequests:
+			pendingCountRequest.Response <- len(pending) + p.client.offeredCount()
+
+		case terminationSignal = <-terminationSignals:
+
+		case paused = <-pauseRequests:
+
+		case expectedMembers = <-expectRequests:
+			checkGroupReady()
+
+		case newMember, ok := <-inserts:
 			if !ok {
 				p.client.Close()
 				insertEvents = nil
 				break
 			}
 
-			process := ifrit.Background(newMember)
-			processes.Add(newMember.Name, process)
+			if paused {
+				reject(newMember, RejectedGroupPaused)
+				break
+			}
+
+			if _, ok := processes.Get(newMember.Name); ok {
+				reject(newMember, RejectedDuplicateName)
+				break
+			}
+
+			pending = append(pending, newMember)
+			startPending()
 
-			if processes.Length() == p.poolSize {
+			if len(pending) > 0 || processes.Full(p.poolSize) {
 				insertEvents = nil
 			}
 
-			invoking++
+		case insertAllRequest := <-insertAllRequests:
+			p.client.offer(-len(insertAllRequest.Members))
+			if paused {
+				insertAllRequest.Response <- ErrMemberRejected{Reason: RejectedGroupPaused}
+				break
+			}
+
+			err := processes.Admissible(insertAllRequest.Members)
+			if err != nil {
+				insertAllRequest.Response <- err
+				break
+			}
 
-			go waitForEvents(newMember, process, entranceEvents, exitEvents)
+			pending = append(pending, insertAllRequest.Members...)
+			startPending()
+
+			if len(pending) > 0 || processes.Full(p.poolSize) {
+				insertEvents = nil
+			}
+			insertAllRequest.Response <- nil
+
+		case replaceRequest := <-replaceRequests:
+			if _, ok := processes.Get(replaceRequest.OldName); !ok {
+				replaceRequest.Response <- ErrMemberNotFound{replaceRequest.OldName}
+				break
+			}
+			if _, ok := processes.Get(replaceRequest.Member.Name); ok {
+				replaceRequest.Response <- ErrDuplicateNames{[]string{replaceRequest.Member.Name}}
+				break
+			}
+
+			// The replacement is started regardless of capacity, as the member it
+			// replaces will soon release its share.
+			start(replaceRequest.Member)
+
+			if processes.Full(p.poolSize) {
+				insertEvents = nil
+			}
+			replaceRequest.Response <- nil
 
 		case entranceEvent := <-entranceEvents:
-			invoking--
-			p.client.broadcastEntrance(entranceEvent)
+			receiveEntrance(entranceEvent)
 
-			if closeNotifier == nil && invoking == 0 {
-				p.client.closeEntranceBroadcaster()
-				entranceEvents = nil
+			if !processes.Signaled() && closeNotifier != nil && len(pending) == 0 && !processes.Full(p.poolSize) {
+				insertEvents = p.client.insertEventListener()
 			}
 
 		case exitEvent := <-exitEvents:
+			// A member's entrance is always sent before its exit, but with
+			// buffered event channels it may not have been received yet.
+			for drained := false; !drained; {
+				select {
+				case entranceEvent := <-entranceEvents:
+					receiveEntrance(entranceEvent)
+				default:
+					drained = true
+				}
+			}
+
 			processes.Remove(exitEvent.Member.Name)
+			p.client.stats.exited(exitEvent.Err)
+			p.client.broadcastCount(processes.Length())
 			p.client.broadcastExit(exitEvent)
+			if p.collectErrors {
+				errTrace = append(errTrace, exitEvent)
+			}
+			if failure == nil && exitEvent.Err != nil && !exitEvent.Member.Optional() {
+				failure = ErrorTrace{exitEvent}
+			}
 
-			if !processes.Signaled() && p.terminationSignal != nil {
-				processes.Signal(p.terminationSignal)
+			if !processes.Signaled() && terminationSignal != nil && !exitEvent.Member.Optional() && p.exitMode.stops(exitEvent.Err) {
 				p.client.Close()
+				beginShutdown()
+				processes.Signal(terminationSignal)
 				insertEvents = nil
+				rejectPending()
+			}
+
+			if !processes.Signaled() {
+				startPending()
 			}
 
-			if processes.Complete() || (processes.Length() == 0 && insertEvents == nil) {
-				return p.client.closeBroadcasters()
+			if processes.Complete() || (processes.Length() == 0 && insertEvents == nil && len(pending) == 0) {
+				return finish()
 			}
 
-			if !processes.Signaled() && closeNotifier != nil {
+			if !processes.Signaled() && closeNotifier != nil && len(pending) == 0 && !processes.Full(p.poolSize) {
 				insertEvents = p.client.insertEventListener()
 			}
 		}
 	}
 }
 
-func waitForEvents(
+func (p *dynamicGroup) waitForEvents(
 	member Member,
 	process ifrit.Process,
 	entrance entranceEventChannel,
 	exit exitEventChannel,
+	stopping <-chan struct{},
+	done <-chan struct{},
 ) {
-	select {
-	case <-process.Ready():
-		entrance <- EntranceEvent{
-			Member:  member,
-			Process: process,
+	// A panic while supervising the member, such as in a hook or a misbehaving
+	// Drainable, is reported as the member's exit, so that the group continues
+	// to supervise the other members.  The member is killed, as it is no longer
+	// supervised once its exit has been reported.
+	entered := false
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
 		}
+		process.Signal(os.Kill)
 
-		exit <- ExitEvent{
-			Member: member,
-			Err:    <-process.Wait(),
+		if !entered {
+			select {
+			case entrance <- EntranceEvent{Member: member, Process: process}:
+			case <-done:
+				return
+			}
 		}
 
-	case err := <-process.Wait():
-		entrance <- EntranceEvent{
-			Member:  member,
-			Process: process,
+		select {
+		case exit <- newExitEvent(member, ifrit.PanicError{Value: value, Stack: debug.Stack()}):
+		case <-done:
 		}
+	}()
+
+	started := time.Now()
+	p.metrics.memberStarted(member.Name)
+	finishStartup := startMemberSpan(p.tracer, "startup", member)
+	stuck, stopWatchdog := p.startWatchdog()
 
-		exit <- ExitEvent{
-			Member: member,
-			Err:    err,
+	for {
+		select {
+		case <-stuck:
+			stuck = nil
+			p.onMemberStuck(member, time.Since(started))
+
+		case <-process.Ready():
+			stopWatchdog()
+			p.metrics.memberReady(member.Name, time.Since(started))
+			finishStartup(nil)
+			finishRun := startMemberSpan(p.tracer, "run", member)
+
+			select {
+			case entrance <- EntranceEvent{Member: member, Process: process}:
+			case <-done:
+				return
+			}
+			entered = true
+
+			err := <-process.Wait()
+			p.metrics.memberExited(member.Name, time.Since(started), err)
+			finishRun(err)
+
+			if !awaitDrain(member, stopping, done) {
+				return
+			}
+
+			select {
+			case exit <- newExitEvent(member, err):
+			case <-done:
+			}
+			return
+
+		case err := <-process.Wait():
+			stopWatchdog()
+			p.metrics.memberExited(member.Name, time.Since(started), err)
+			finishStartup(err)
+
+			select {
+			case entrance <- EntranceEvent{Member: member, Process: process}:
+			case <-done:
+				return
+			}
+			entered = true
+
+			if !awaitDrain(member, stopping, done) {
+				return
+			}
+
+			select {
+			case exit <- newStartupExitEvent(member, err):
+			case <-done:
+			}
+			return
 		}
 	}
 }
 
+// memberSignal is the signal sent to a member by SignalMember, SignalSelect or
+// RestartFrom: a nil signal is replaced by the group's termination signal, or
+// os.Interrupt.
+func memberSignal(signal os.Signal, terminationSignal os.Signal) os.Signal {
+	if signal != nil {
+		return signal
+	}
+	if terminationSignal != nil {
+		return terminationSignal
+	}
+	return os.Interrupt
+}
+
+// awaitDrain waits for a Drainable member which exited while the group was
+// shutting down to finish draining.  It returns false if the group exited first.
+func awaitDrain(member Member, stopping <-chan struct{}, done <-chan struct{}) bool {
+	drainable, ok := member.runner().(Drainable)
+	if !ok {
+		return true
+	}
+
+	select {
+	case <-stopping:
+	default:
+		return true
+	}
+
+	select {
+	case <-drainable.Drain():
+		return true
+	case <-done:
+		return false
+	}
+}
+
+func (p *dynamicGroup) startWatchdog() (<-chan time.Time, func()) {
+	if p.onMemberStuck == nil || p.stuckThreshold <= 0 {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(p.stuckThreshold)
+	return timer.C, func() { timer.Stop() }
+}
+
 type processSet struct {
 	processes map[string]ifrit.Process
+	members   map[string]Member
+	readyAt   map[string]time.Time
+	weights   map[string]int
+	weight    int
 	shutdown  os.Signal
 }
 
 func newProcessSet() *processSet {
 	return &processSet{
 		processes: map[string]ifrit.Process{},
+		members:   map[string]Member{},
+		readyAt:   map[string]time.Time{},
+		weights:   map[string]int{},
 	}
 }
 
@@ -182,16 +733,61 @@ func (g *processSet) Signaled() bool {
 
 func (g *processSet) Signal(signal os.Signal) {
 	g.shutdown = signal
+	g.Forward(signal)
+}
 
+// Forward sends the signal to every process without shutting the set down, so
+// that a drain signal leaves the group running.
+func (g *processSet) Forward(signal os.Signal) {
 	for _, p := range g.processes {
 		p.Signal(signal)
 	}
 }
 
+func (g *processSet) Names() []string {
+	names := make([]string, 0, len(g.processes))
+	for name := range g.processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (g *processSet) Length() int {
 	return len(g.processes)
 }
 
+// Fits reports whether a member of the given weight can be started within the
+// capacity.  A member always fits in an empty set, so that a member heavier
+// than the capacity runs alone rather than waiting forever.
+func (g *processSet) Fits(weight int, capacity int) bool {
+	return capacity <= 0 || len(g.processes) == 0 || g.weight+weight <= capacity
+}
+
+func (g *processSet) Full(capacity int) bool {
+	return capacity > 0 && g.weight >= capacity
+}
+
+// Admissible checks that the members are valid, and that none of their names
+// are already running, which is reported as ErrDuplicateNames.
+func (g *processSet) Admissible(members Members) error {
+	err := members.Validate()
+	if err != nil {
+		return err
+	}
+
+	duplicateNames := []string{}
+	for _, member := range members {
+		if _, ok := g.processes[member.Name]; ok {
+			duplicateNames = append(duplicateNames, member.Name)
+		}
+	}
+	if len(duplicateNames) > 0 {
+		return ErrDuplicateNames{duplicateNames}
+	}
+	return nil
+}
+
 func (g *processSet) Complete() bool {
 	return len(g.processes) == 0 && g.shutdown != nil
 }
@@ -201,14 +797,91 @@ func (g *processSet) Get(name string) (ifrit.Process, bool) {
 	return p, ok
 }
 
-func (g *processSet) Add(name string, process ifrit.Process) {
-	_, ok := g.processes[name]
+func (g *processSet) Add(member Member, process ifrit.Process) {
+	_, ok := g.processes[member.Name]
 	if ok {
-		panic(fmt.Errorf("member inserted twice: %#v", name))
+		panic(fmt.Errorf("member inserted twice: %#v", member.Name))
 	}
-	g.processes[name] = process
+	g.processes[member.Name] = process
+	g.members[member.Name] = member
+	g.weights[member.Name] = member.weight()
+	g.weight += member.weight()
 }
 
 func (g *processSet) Remove(name string) {
+	g.weight -= g.weights[name]
 	delete(g.processes, name)
+	delete(g.members, name)
+	delete(g.readyAt, name)
+	delete(g.weights, name)
+}
+
+// Select returns the status of the members matching the selector, ordered by
+// name.
+func (g *processSet) Select(selector labelSelector) []MemberStatus {
+	statuses := []MemberStatus{}
+	for _, name := range g.Names() {
+		member := g.members[name]
+		if !selector.Matches(member.labels()) {
+			continue
+		}
+		process := g.processes[name]
+		statuses = append(statuses, MemberStatus{
+			Member:  member,
+			Process: process,
+			State:   process.State(),
+		})
+	}
+	return statuses
+}
+
+func (g *processSet) MarkReady(name string, at time.Time) {
+	if _, ok := g.processes[name]; ok {
+		g.readyAt[name] = at
+	}
+}
+
+func (g *processSet) Uptime(name string) (time.Duration, bool) {
+	at, ok := g.readyAt[name]
+	if !ok {
+		return 0, false
+	}
+	return time.Since(at), true
+}
+
+// Reasons given by ErrMemberRejected.
+const (
+	RejectedDuplicateName = "duplicate name"
+	RejectedGroupStopped  = "group stopped"
+	RejectedGroupClosed   = "group closed"
+	RejectedGroupPaused   = "group paused"
+)
+
+/*
+ErrMemberRejected is the error of a synthetic exit event, emitted for an
+inserted member which was never started: either a member of the same name was
+already running, or the group was signaled while the member waited for
+capacity.  It is also returned by InsertAll once the group is closed.  A group
+configured to RejectWhilePaused also rejects members inserted while paused.
+*/
+type ErrMemberRejected struct {
+	Reason string
+}
+
+func (e ErrMemberRejected) Error() string {
+	return fmt.Sprintf("Member rejected: %s", e.Reason)
+}
+
+/*
+ErrShutdownDeadlineExceeded is returned by a dynamic group whose members did not
+all exit within its ShutdownDeadline, or by an ordered group whose members did
+not each exit within its StopTimeout.  Members lists the members which were
+still running.
+*/
+type ErrShutdownDeadlineExceeded struct {
+	Members []string
+}
+
+func (e ErrShutdownDeadlineExceeded) Error() string {
+	return fmt.Sprintf("Members did not exit before the shutdown deadline: %s", strings.Join(e.Members, ", "))
 }
@@ -0,0 +1,53 @@
+package grouper_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+)
+
+// BenchmarkDynamicGroupBurst measures a dynamic group whose members all become
+// ready and exit at once, with and without an internal event buffer.
+func BenchmarkDynamicGroupBurst(b *testing.B) {
+	const numMembers = 200
+
+	for _, buffer := range []int{0, numMembers} {
+		b.Run(fmt.Sprintf("buffer-%d", buffer), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				release := make(chan struct{})
+				runner := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					<-release
+					close(ready)
+					return nil
+				})
+
+				members := make(grouper.Members, numMembers)
+				for j := range members {
+					members[j] = grouper.Member{Name: fmt.Sprintf("member-%d", j), Runner: runner}
+				}
+
+				pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+					MaxCapacity:         numMembers,
+					InternalEventBuffer: buffer,
+				})
+				client := pool.Client()
+				process := ifrit.Background(pool)
+
+				err := client.InsertAll(members)
+				if err != nil {
+					b.Fatal(err)
+				}
+				client.Close()
+
+				close(release)
+				err = <-process.Wait()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package grouper_test
 
 import (
+	"context"
+	"errors"
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -66,6 +69,1872 @@ var _ = Describe("dynamicGroup", func() {
 		})
 	})
 
+	Describe("SignalMember", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("signals only the named member", func() {
+			signal1 := childRunner1.WaitForCall()
+			signal2 := childRunner2.WaitForCall()
+
+			Ω(client.SignalMember("child1", syscall.SIGUSR2)).Should(Succeed())
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signal2).ShouldNot(Receive())
+		})
+
+		It("reports the member's exit as usual", func() {
+			exits := client.ExitListener()
+			childRunner1.WaitForCall()
+
+			Ω(client.StopMember("child1")).Should(Succeed())
+			childRunner1.TriggerExit(nil)
+
+			Eventually(exits).Should(Receive(Equal(grouper.ExitEvent{Member: grouper.Member{"child1", childRunner1}})))
+			_, ok := client.Get("child1")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("stops the member with os.Interrupt when there is no termination signal", func() {
+			signal1 := childRunner1.WaitForCall()
+			Ω(client.StopMember("child1")).Should(Succeed())
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("returns an error when the member is not present", func() {
+			Ω(client.SignalMember("blah", os.Kill)).Should(Equal(grouper.ErrMemberNotFound{"blah"}))
+			Ω(client.StopMember("blah")).Should(Equal(grouper.ErrMemberNotFound{"blah"}))
+		})
+
+		Context("when the group has a termination signal", func() {
+			BeforeEach(func() {
+				pool = grouper.NewDynamic(syscall.SIGUSR1, 3, 2)
+				client = pool.Client()
+				poolProcess = ifrit.Envoke(pool)
+
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child3", childRunner3}))
+			})
+
+			It("stops the member with the termination signal", func() {
+				signal3 := childRunner3.WaitForCall()
+				Ω(client.StopMember("child3")).Should(Succeed())
+				Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR1)))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		It("can be called concurrently, more than once", func() {
+			exits := client.ExitListener()
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			childRunner1.TriggerReady()
+
+			start := make(chan struct{})
+			var closers sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				closers.Add(1)
+				go func(client grouper.DynamicClient) {
+					defer GinkgoRecover()
+					defer closers.Done()
+					<-start
+					client.Close()
+				}(client)
+			}
+			close(start)
+			closers.Wait()
+
+			Eventually(client.CloseNotifier()).Should(BeClosed())
+			childRunner1.TriggerExit(nil)
+			client.Close()
+
+			Eventually(exits).Should(Receive())
+			Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+			client.Close()
+		})
+	})
+
+	Describe("Closed and Signaled", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(os.Interrupt, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("reports neither while the group accepts members", func() {
+			Ω(client.Closed()).Should(BeFalse())
+			Ω(client.Signaled()).Should(BeFalse())
+		})
+
+		It("reports closed, but not signaled, once the group is closed", func() {
+			client.Close()
+			Ω(client.Closed()).Should(BeTrue())
+			Consistently(client.Signaled).Should(BeFalse())
+		})
+
+		It("reports both once the group is signaled", func() {
+			poolProcess.Signal(syscall.SIGUSR2)
+			Eventually(client.Signaled).Should(BeTrue())
+			Ω(client.Closed()).Should(BeTrue())
+		})
+
+		It("reports both once a member exits and the group propagates the termination signal", func() {
+			childRunner1.TriggerExit(nil)
+			Eventually(client.Signaled).Should(BeTrue())
+			Ω(client.Closed()).Should(BeTrue())
+		})
+	})
+
+	Describe("Uptime", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("returns how long a ready member has been ready", func() {
+			entrances := client.EntranceListener()
+			childRunner1.TriggerReady()
+			Eventually(entrances).Should(Receive())
+
+			time.Sleep(10 * time.Millisecond)
+
+			uptime, ok := client.Uptime("child1")
+			Ω(ok).Should(BeTrue())
+			Ω(uptime).Should(BeNumerically(">=", 10*time.Millisecond))
+		})
+
+		It("returns false for a member which is not ready", func() {
+			childRunner2.WaitForCall()
+			_, ok := client.Uptime("child2")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("returns false for a member which is not present", func() {
+			_, ok := client.Uptime("blah")
+			Ω(ok).Should(BeFalse())
+		})
+	})
+
+	Describe("EntranceListenerFor", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("only emits events for the named members", func() {
+			all := client.EntranceListener()
+			childRunner1.TriggerReady()
+			Eventually(all).Should(Receive())
+
+			entrances := client.EntranceListenerFor("child1", "child3")
+
+			var entrance grouper.EntranceEvent
+			Eventually(entrances).Should(Receive(&entrance))
+			Ω(entrance.Member.Name).Should(Equal("child1"))
+			Ω(entrance.Replayed).Should(BeTrue())
+
+			childRunner2.TriggerReady()
+			Eventually(all).Should(Receive())
+			Consistently(entrances).ShouldNot(Receive())
+
+			childRunner3.TriggerReady()
+			Eventually(entrances).Should(Receive(&entrance))
+			Ω(entrance.Member.Name).Should(Equal("child3"))
+		})
+	})
+
+	Describe("RecoverPanics", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				MaxCapacity:     3,
+				EventBufferSize: 3,
+				RecoverPanics:   true,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("turns a panicking member into an exit event, and keeps the group running", func() {
+			exits := client.ExitListener()
+			panicking := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				panic("boom")
+			})
+
+			client.Inserter() <- grouper.Member{Name: "child1", Runner: childRunner1}
+			client.Inserter() <- grouper.Member{Name: "panicking", Runner: panicking}
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("panicking"))
+			var panicErr ifrit.PanicError
+			Ω(errors.As(exit.Err, &panicErr)).Should(BeTrue())
+			Ω(panicErr.Value).Should(Equal("boom"))
+
+			signal1 := childRunner1.WaitForCall()
+			Consistently(signal1).ShouldNot(Receive())
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+	})
+
+	Describe("StartupError", func() {
+		var exits <-chan grouper.ExitEvent
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+			exits = client.ExitListener()
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(poolProcess.Wait()).Should(Receive())
+		})
+
+		It("wraps the error of a member which exits before becoming ready", func() {
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Err).Should(Equal(grouper.StartupError{
+				Member: grouper.Member{Name: "child1", Runner: childRunner1},
+				Err:    errors.New("boom"),
+			}))
+		})
+
+		It("does not wrap the error of a member which exits after becoming ready", func() {
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Err).Should(Equal(errors.New("boom")))
+		})
+	})
+
+	Describe("Replayed events", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("marks events which occurred before the listener attached", func() {
+			early := client.EntranceListener()
+			client.Inserter() <- grouper.Member{Name: "child1", Runner: childRunner1}
+			childRunner1.TriggerReady()
+			Eventually(early).Should(Receive())
+
+			entrances := client.EntranceListener()
+			exits := client.ExitListener()
+
+			var entrance grouper.EntranceEvent
+			Eventually(entrances).Should(Receive(&entrance))
+			Ω(entrance.Member.Name).Should(Equal("child1"))
+			Ω(entrance.Replayed).Should(BeTrue())
+
+			client.Inserter() <- grouper.Member{Name: "child2", Runner: childRunner2}
+			childRunner2.TriggerReady()
+			Eventually(entrances).Should(Receive(&entrance))
+			Ω(entrance.Member.Name).Should(Equal("child2"))
+			Ω(entrance.Replayed).Should(BeFalse())
+
+			childRunner1.TriggerExit(nil)
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child1"))
+			Ω(exit.Replayed).Should(BeFalse())
+
+			lateExits := client.ExitListener()
+			Eventually(lateExits).Should(Receive(&exit))
+			Ω(exit.Replayed).Should(BeTrue())
+		})
+	})
+
+	Describe("WaitMember", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		type result struct {
+			err error
+			ok  bool
+		}
+
+		waitMember := func(name string) <-chan result {
+			results := make(chan result, 1)
+			go func() {
+				err, ok := client.WaitMember(name)
+				results <- result{err, ok}
+			}()
+			return results
+		}
+
+		It("returns the member's exit error once it exits", func() {
+			results := waitMember("child1")
+			Consistently(results).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			var r result
+			Eventually(results).Should(Receive(&r))
+			Ω(r.ok).Should(BeTrue())
+			Ω(r.err).Should(MatchError("boom"))
+		})
+
+		It("returns the exit error of a member which has already exited", func() {
+			exits := client.ExitListener()
+			childRunner2.TriggerExit(errors.New("boom"))
+			Eventually(exits).Should(Receive())
+
+			err, ok := client.WaitMember("child2")
+			Ω(ok).Should(BeTrue())
+			Ω(err).Should(MatchError("boom"))
+		})
+
+		It("returns false for a member which was never present", func() {
+			err, ok := client.WaitMember("blah")
+			Ω(ok).Should(BeFalse())
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("SetTerminationSignal", func() {
+		var signal1 <-chan os.Signal
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(syscall.SIGUSR1, 3, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+		})
+
+		AfterEach(func() {
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("propagates the new signal when a member exits", func() {
+			client.SetTerminationSignal(syscall.SIGUSR2)
+			childRunner2.TriggerExit(nil)
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+		})
+
+		It("does not change a signal which has already been propagated", func() {
+			childRunner2.TriggerExit(nil)
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+
+			client.SetTerminationSignal(syscall.SIGUSR2)
+			Consistently(signal1).ShouldNot(Receive())
+		})
+
+		It("disables propagation when set to nil", func() {
+			client.SetTerminationSignal(nil)
+			childRunner2.TriggerExit(nil)
+
+			Consistently(signal1).ShouldNot(Receive())
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+			poolProcess.Signal(os.Kill)
+		})
+	})
+
+	Describe("Run's error", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(os.Interrupt, 2, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+		})
+
+		It("returns an ErrorTrace of the first member which failed", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+
+			exits := client.ExitListener()
+			childRunner1.TriggerExit(errors.New("boom"))
+			Eventually(exits).Should(Receive())
+			childRunner2.TriggerExit(errors.New("interrupted"))
+
+			var err error
+			Eventually(poolProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(1))
+			Ω(errTrace[0].Member.Name).Should(Equal("child1"))
+			Ω(errTrace[0].Err).Should(MatchError("boom"))
+		})
+
+		It("does not return the error of an Optional member", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: grouper.Optional(childRunner1)}))
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			client.Close()
+			Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("ExitMode", func() {
+		var signal2 <-chan os.Signal
+
+		start := func(mode grouper.ExitMode) {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				TerminationSignal: os.Interrupt,
+				MaxCapacity:       2,
+				EventBufferSize:   2,
+				ExitMode:          mode,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			childRunner1.TriggerReady()
+			signal2 = childRunner2.WaitForCall()
+		}
+
+		exit := func(err error) {
+			exits := client.ExitListener()
+			childRunner1.TriggerExit(err)
+			Eventually(exits).Should(Receive())
+		}
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		Context("StopOnAnyExit", func() {
+			BeforeEach(func() {
+				start(grouper.StopOnAnyExit)
+			})
+
+			It("shuts down on a clean exit", func() {
+				exit(nil)
+				Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			})
+
+			It("shuts down on an error exit", func() {
+				exit(errors.New("boom"))
+				Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			})
+		})
+
+		Context("StopOnErrorExit", func() {
+			BeforeEach(func() {
+				start(grouper.StopOnErrorExit)
+			})
+
+			It("keeps running after a clean exit", func() {
+				exit(nil)
+				Consistently(signal2).ShouldNot(Receive())
+				Ω(client.Closed()).Should(BeFalse())
+			})
+
+			It("shuts down on an error exit", func() {
+				exit(errors.New("boom"))
+				Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			})
+		})
+
+		Context("StopNever", func() {
+			BeforeEach(func() {
+				start(grouper.StopNever)
+			})
+
+			It("keeps running after a clean exit", func() {
+				exit(nil)
+				Consistently(signal2).ShouldNot(Receive())
+				Ω(client.Closed()).Should(BeFalse())
+			})
+
+			It("keeps running after an error exit", func() {
+				exit(errors.New("boom"))
+				Consistently(signal2).ShouldNot(Receive())
+				Ω(client.Closed()).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("CollectErrors", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				TerminationSignal: os.Interrupt,
+				MaxCapacity:       2,
+				EventBufferSize:   2,
+				CollectErrors:     true,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+		})
+
+		It("returns an ErrorTrace naming the failed member", func() {
+			exits := client.ExitListener()
+			childRunner1.TriggerExit(errors.New("boom"))
+			Eventually(exits).Should(Receive())
+
+			childRunner2.TriggerExit(nil)
+
+			var err error
+			Eventually(poolProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(2))
+			Ω(errTrace[0].Member.Name).Should(Equal("child1"))
+			Ω(errTrace[0].Err).Should(MatchError("boom"))
+		})
+
+		It("returns nil when every member exits cleanly", func() {
+			poolProcess.Signal(os.Interrupt)
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+
+			Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("ExpectMembers", func() {
+		var groupReady <-chan grouper.GroupReadyEvent
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+			groupReady = client.GroupReadyListener()
+
+			client.ExpectMembers(2)
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("emits a single GroupReadyEvent once the expected members are ready", func() {
+			childRunner1.TriggerReady()
+			Consistently(groupReady).ShouldNot(Receive())
+
+			childRunner2.TriggerReady()
+			Eventually(groupReady).Should(Receive(Equal(grouper.GroupReadyEvent{Members: 2})))
+
+			childRunner3.TriggerReady()
+			Consistently(groupReady).ShouldNot(Receive())
+
+			Ω(client.GroupReadyListener()).Should(Receive(Equal(grouper.GroupReadyEvent{Members: 2})))
+		})
+
+		It("does not count members which exit before becoming ready", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerExit(nil)
+			Consistently(groupReady).ShouldNot(Receive())
+		})
+
+		It("never emits the event if the count is not reached", func() {
+			childRunner1.TriggerReady()
+
+			poolProcess.Signal(os.Interrupt)
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+			Eventually(poolProcess.Wait()).Should(Receive())
+
+			Ω(groupReady).Should(BeClosed())
+		})
+	})
+
+	Describe("Select", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: grouper.Labeled(childRunner1, map[string]string{"team": "payments", "tier": "critical"})},
+				{Name: "child2", Runner: grouper.Labeled(childRunner2, map[string]string{"team": "payments", "tier": "noncritical"})},
+				{Name: "child3", Runner: grouper.Labeled(childRunner3, map[string]string{"team": "search"})},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			childRunner1.TriggerReady()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		names := func(statuses []grouper.MemberStatus) []string {
+			names := []string{}
+			for _, status := range statuses {
+				names = append(names, status.Member.Name)
+			}
+			return names
+		}
+
+		It("selects members matching every term", func() {
+			Ω(names(client.Select("team=payments"))).Should(Equal([]string{"child1", "child2"}))
+			Ω(names(client.Select("team=payments, tier=critical"))).Should(Equal([]string{"child1"}))
+			Ω(names(client.Select("tier"))).Should(Equal([]string{"child1", "child2"}))
+			Ω(names(client.Select(""))).Should(Equal([]string{"child1", "child2", "child3"}))
+		})
+
+		It("reports each selected member's process and state", func() {
+			statuses := client.Select("tier=critical")
+			Ω(statuses).Should(HaveLen(1))
+			Ω(statuses[0].Member.Labels()).Should(HaveKeyWithValue("team", "payments"))
+			Eventually(statuses[0].Process.Ready()).Should(BeClosed())
+			Ω(statuses[0].State).Should(Equal(ifrit.StateReady))
+		})
+
+		It("selects nothing when no member matches", func() {
+			Ω(client.Select("team=billing")).Should(BeEmpty())
+			Ω(client.Select("team=search,tier=critical")).Should(BeEmpty())
+		})
+
+		It("does not select members which have exited", func() {
+			childRunner3.TriggerExit(nil)
+			Eventually(func() []string {
+				return names(client.Select("team=search"))
+			}).Should(BeEmpty())
+		})
+	})
+
+	Describe("SignalSelect", func() {
+		var signal1, signal2, signal3 <-chan os.Signal
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: grouper.Labeled(childRunner1, map[string]string{"tier": "critical"})},
+				{Name: "child2", Runner: grouper.Labeled(childRunner2, map[string]string{"tier": "noncritical"})},
+				{Name: "child3", Runner: grouper.Labeled(childRunner3, map[string]string{"tier": "noncritical"})},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			signal1 = childRunner1.WaitForCall()
+			signal2 = childRunner2.WaitForCall()
+			signal3 = childRunner3.WaitForCall()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("signals only the matching members, whose exits are handled as usual", func() {
+			exits := client.ExitListener()
+			Ω(client.SignalSelect("tier=noncritical", syscall.SIGUSR2)).Should(Equal(2))
+
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+			Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signal1).ShouldNot(Receive())
+
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+			Eventually(exits).Should(Receive())
+			Eventually(exits).Should(Receive())
+
+			Ω(client.Select("")).Should(HaveLen(1))
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+
+		It("does not signal members which have already exited", func() {
+			exits := client.ExitListener()
+			childRunner2.TriggerExit(nil)
+			Eventually(exits).Should(Receive())
+
+			Ω(client.SignalSelect("tier=noncritical", syscall.SIGUSR2)).Should(Equal(1))
+			Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+		})
+
+		It("signals nothing when no member matches", func() {
+			Ω(client.SignalSelect("tier=unknown", syscall.SIGUSR2)).Should(BeZero())
+			Consistently(signal1).ShouldNot(Receive())
+		})
+	})
+
+	Describe("Drain", func() {
+		var signal1, signal2 <-chan os.Signal
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				TerminationSignal: os.Interrupt,
+				MaxCapacity:       3,
+				EventBufferSize:   3,
+				DrainSignal:       syscall.SIGUSR1,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			signal1 = childRunner1.WaitForCall()
+			signal2 = childRunner2.WaitForCall()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("sends the drain signal to every member, and keeps running until terminated", func() {
+			client.Drain()
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR1)))
+			Consistently(poolProcess.Wait()).ShouldNot(Receive())
+			Ω(client.Closed()).Should(BeFalse())
+			Ω(client.Signaled()).Should(BeFalse())
+
+			poolProcess.Signal(os.Interrupt)
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("drains when the group receives the drain signal", func() {
+			poolProcess.Signal(syscall.SIGUSR1)
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR1)))
+			Consistently(poolProcess.Wait()).ShouldNot(Receive())
+			Ω(client.Closed()).Should(BeFalse())
+		})
+
+		It("keeps accepting inserts after the group receives the drain signal", func() {
+			poolProcess.Signal(syscall.SIGUSR1)
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR1)))
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child3", childRunner3}))
+			signal3 := childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+			Ω(client.Signaled()).Should(BeFalse())
+			Consistently(poolProcess.Wait()).ShouldNot(Receive())
+
+			poolProcess.Signal(os.Interrupt)
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("handles a member which exits while draining like any other exit", func() {
+			exits := client.ExitListener()
+			client.Drain()
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+
+			childRunner1.TriggerExit(nil)
+			Eventually(exits).Should(Receive(WithTransform(func(e grouper.ExitEvent) string {
+				return e.Member.Name
+			}, Equal("child1"))))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR1)))
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+		})
+	})
+
+	Describe("Drain without a DrainSignal", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 1, 1)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("does nothing", func() {
+			Ω(client.InsertAll(grouper.Members{{Name: "child1", Runner: childRunner1}})).Should(Succeed())
+			signal1 := childRunner1.WaitForCall()
+
+			client.Drain()
+			Consistently(signal1).ShouldNot(Receive())
+		})
+	})
+
+	Describe("context listeners", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 1)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("closes the listeners once the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			exits := client.ExitListenerCtx(ctx)
+			entrances := client.EntranceListenerCtx(ctx)
+
+			childRunner1.TriggerReady()
+			Eventually(entrances).Should(Receive())
+
+			cancel()
+			Eventually(exits).Should(BeClosed())
+			Eventually(entrances).Should(BeClosed())
+		})
+
+		It("does not let an abandoned listener block the group", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			client.ExitListenerCtx(ctx)
+
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			Eventually(func() int {
+				return client.Stats().ExitedCleanly
+			}).Should(Equal(2))
+
+			found := make(chan bool, 1)
+			go func() {
+				_, ok := client.Get("child3")
+				found <- ok
+			}()
+			Consistently(found).ShouldNot(Receive())
+
+			cancel()
+			Eventually(found).Should(Receive(BeTrue()))
+
+			childRunner3.TriggerExit(nil)
+			Eventually(func() int {
+				return client.Stats().ExitedCleanly
+			}).Should(Equal(3))
+		})
+
+		It("closes the listeners when the group exits", func() {
+			exits := client.ExitListenerCtx(context.Background())
+
+			poolProcess.Signal(os.Interrupt)
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			Eventually(func() int {
+				n := 0
+				for range exits {
+					n++
+				}
+				return n
+			}).Should(Equal(3))
+		})
+	})
+
+	Describe("detaching listeners", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 1)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("closes a detached listener, which receives no further events", func() {
+			detached := client.ExitListener()
+			attached := client.ExitListener()
+			entrances := client.EntranceListener()
+
+			client.DetachExitListener(detached)
+			client.DetachEntranceListener(entrances)
+			Ω(detached).Should(BeClosed())
+			Ω(entrances).Should(BeClosed())
+
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(nil)
+			Eventually(attached).Should(Receive())
+
+			go func() {
+				for range attached {
+				}
+			}()
+		})
+
+		It("does not broadcast to listeners which were attached and detached", func() {
+			for i := 0; i < 10; i++ {
+				client.DetachExitListener(client.ExitListener())
+				client.DetachEntranceListener(client.EntranceListener())
+			}
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			Eventually(func() int {
+				return client.Stats().ExitedCleanly
+			}).Should(Equal(2))
+
+			_, found := client.Get("child3")
+			Ω(found).Should(BeTrue())
+		})
+
+		It("does nothing for a listener which is already detached", func() {
+			exits := client.ExitListener()
+			client.DetachExitListener(exits)
+			client.DetachExitListener(exits)
+			Ω(exits).Should(BeClosed())
+		})
+	})
+
+	Describe("listener overflow policies", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 1)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		exitMember := func(runner *fake_runner.TestRunner, name string) {
+			runner.TriggerExit(nil)
+			Eventually(func() bool {
+				_, ok := client.Get(name)
+				return ok
+			}).Should(BeFalse())
+		}
+
+		It("blocks the group on a stalled listener by default", func() {
+			exits := client.ExitListenerWithPolicy(grouper.OverflowBlock)
+			exitMember(childRunner1, "child1")
+			childRunner2.TriggerExit(nil)
+			Eventually(func() int {
+				return client.Stats().ExitedCleanly
+			}).Should(Equal(2))
+
+			found := make(chan bool, 1)
+			go func() {
+				_, ok := client.Get("child3")
+				found <- ok
+			}()
+			Consistently(found).ShouldNot(Receive())
+
+			Ω(exits).Should(Receive())
+			Eventually(found).Should(Receive(BeTrue()))
+
+			go func() {
+				for range exits {
+				}
+			}()
+		})
+
+		It("drops the oldest events for a stalled listener", func() {
+			exits := client.ExitListenerWithPolicy(grouper.OverflowDropOldest)
+			exitMember(childRunner1, "child1")
+			exitMember(childRunner2, "child2")
+			exitMember(childRunner3, "child3")
+
+			var exit grouper.ExitEvent
+			Ω(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child3"))
+		})
+
+		It("closes a stalled listener", func() {
+			exits := client.ExitListenerWithPolicy(grouper.OverflowCloseListener)
+			entrances := client.EntranceListenerWithPolicy(grouper.OverflowCloseListener)
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			exitMember(childRunner1, "child1")
+			exitMember(childRunner2, "child2")
+
+			var exit grouper.ExitEvent
+			Ω(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child1"))
+			Ω(exits).Should(BeClosed())
+
+			Ω(entrances).Should(Receive())
+			Ω(entrances).Should(BeClosed())
+
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+	})
+
+	Describe("PendingCount", func() {
+		var childRunner4, childRunner5 *fake_runner.TestRunner
+
+		BeforeEach(func() {
+			childRunner4 = fake_runner.NewTestRunner()
+			childRunner5 = fake_runner.NewTestRunner()
+
+			pool = grouper.NewDynamic(nil, 2, 5)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				childRunner4.EnsureExit()
+				childRunner5.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("counts queued members, and blocked InsertAll calls, until they start", func() {
+			Ω(client.PendingCount()).Should(BeZero())
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			err := client.InsertAll(grouper.Members{
+				{Name: "child2", Runner: childRunner2},
+				{Name: "child3", Runner: childRunner3},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(client.PendingCount()).Should(Equal(1))
+
+			inserted := make(chan error, 1)
+			go func() {
+				inserted <- client.InsertAll(grouper.Members{
+					{Name: "child4", Runner: childRunner4},
+					{Name: "child5", Runner: childRunner5},
+				})
+			}()
+			Eventually(client.PendingCount).Should(Equal(3))
+			Consistently(inserted).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			Ω(client.PendingCount()).Should(Equal(2))
+
+			childRunner2.TriggerExit(nil)
+			Eventually(inserted).Should(Receive(BeNil()))
+			Eventually(childRunner4.RunCallCount).Should(Equal(1))
+			Ω(client.PendingCount()).Should(Equal(1))
+
+			childRunner3.TriggerExit(nil)
+			Eventually(childRunner5.RunCallCount).Should(Equal(1))
+			Ω(client.PendingCount()).Should(BeZero())
+		})
+	})
+
+	Describe("CountListener", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+		})
+
+		It("emits the latest running member count as members start and exit", func() {
+			counts := client.CountListener()
+			Eventually(counts).Should(Receive(Equal(0)))
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(counts).Should(Receive(Equal(1)))
+
+			By("conflating changes the reader has not yet received")
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+			Eventually(func() bool {
+				_, ok := client.Get("child3")
+				return ok
+			}).Should(BeTrue())
+			Ω(counts).Should(Receive(Equal(3)))
+			Consistently(counts).ShouldNot(Receive())
+
+			childRunner1.TriggerReady()
+			Consistently(counts).ShouldNot(Receive())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(counts).Should(Receive(Equal(2)))
+
+			poolProcess.Signal(os.Interrupt)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+			Eventually(poolProcess.Wait()).Should(Receive())
+			Eventually(counts).Should(Receive(Equal(0)))
+			Eventually(counts).Should(BeClosed())
+		})
+	})
+
+	Describe("WaitForCount", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("returns once the target number of members is running", func() {
+			reached := make(chan error, 1)
+			go func() {
+				reached <- client.WaitForCount(context.Background(), 2)
+			}()
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Consistently(reached).ShouldNot(Receive())
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(reached).Should(Receive(BeNil()))
+		})
+
+		It("returns once more than the target number of members is running", func() {
+			counts := client.CountListener()
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(counts).Should(Receive(Equal(2)))
+
+			Ω(client.WaitForCount(context.Background(), 1)).Should(Succeed())
+		})
+
+		It("returns the context's error if it is cancelled first", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			reached := make(chan error, 1)
+			go func() {
+				reached <- client.WaitForCount(ctx, 2)
+			}()
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Consistently(reached).ShouldNot(Receive())
+
+			cancel()
+			Eventually(reached).Should(Receive(Equal(context.Canceled)))
+		})
+
+		It("returns ErrCountNotReached if the group exits first", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			client.Close()
+			childRunner1.TriggerExit(nil)
+			Eventually(poolProcess.Wait()).Should(Receive())
+
+			Ω(client.WaitForCount(context.Background(), 2)).Should(Equal(grouper.ErrCountNotReached{Target: 2, Count: 0}))
+		})
+	})
+
+	Describe("InternalEventBuffer", func() {
+		It("emits every member's entrance and exit", func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				MaxCapacity:         3,
+				EventBufferSize:     3,
+				InternalEventBuffer: 3,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Background(pool)
+			entrances := client.EntranceListener()
+			exits := client.ExitListener()
+
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+				{Name: "child3", Runner: childRunner3},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			client.Close()
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+
+			entered := map[string]bool{}
+			for entrance := range entrances {
+				entered[entrance.Member.Name] = true
+			}
+			Ω(entered).Should(HaveLen(3))
+
+			exited := 0
+			for range exits {
+				exited++
+			}
+			Ω(exited).Should(Equal(3))
+		})
+	})
+
+	Describe("Optional members", func() {
+		var signal1 <-chan os.Signal
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(syscall.SIGUSR1, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child2", Runner: grouper.Optional(childRunner2)}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("broadcasts an optional member's exit without propagating the termination signal", func() {
+			exits := client.ExitListener()
+			childRunner2.TriggerExit(errors.New("optional failure"))
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child2"))
+			Ω(exit.Err).Should(MatchError("optional failure"))
+
+			Consistently(signal1).ShouldNot(Receive())
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+
+		It("propagates the termination signal when a required member exits", func() {
+			childRunner3.TriggerExit(errors.New("required failure"))
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR1)))
+		})
+	})
+
+	Describe("Pause and Resume", func() {
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		Context("by default", func() {
+			BeforeEach(func() {
+				pool = grouper.NewDynamic(nil, 3, 3)
+				client = pool.Client()
+				poolProcess = ifrit.Envoke(pool)
+
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: childRunner1}))
+				client.Pause()
+			})
+
+			It("blocks inserts until resumed, leaving running members alone", func() {
+				Consistently(client.Inserter()).ShouldNot(BeSent(grouper.Member{Name: "child2", Runner: childRunner2}))
+
+				inserted := make(chan error, 1)
+				go func() {
+					inserted <- client.InsertAll(grouper.Members{{Name: "child3", Runner: childRunner3}})
+				}()
+				Consistently(inserted).ShouldNot(Receive())
+
+				childRunner1.TriggerReady()
+				_, ok := client.Get("child1")
+				Ω(ok).Should(BeTrue())
+
+				client.Resume()
+
+				Eventually(inserted).Should(Receive(BeNil()))
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child2", Runner: childRunner2}))
+				Eventually(childRunner2.RunCallCount).Should(Equal(1))
+				Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			})
+		})
+
+		Context("when configured to reject while paused", func() {
+			BeforeEach(func() {
+				pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+					MaxCapacity:       3,
+					EventBufferSize:   3,
+					RejectWhilePaused: true,
+				})
+				client = pool.Client()
+				poolProcess = ifrit.Envoke(pool)
+				client.Pause()
+			})
+
+			It("rejects inserts until resumed", func() {
+				exits := client.ExitListener()
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: childRunner1}))
+
+				var exit grouper.ExitEvent
+				Eventually(exits).Should(Receive(&exit))
+				Ω(exit.Member.Name).Should(Equal("child1"))
+				Ω(exit.Err).Should(Equal(grouper.ErrMemberRejected{Reason: grouper.RejectedGroupPaused}))
+
+				err := client.InsertAll(grouper.Members{{Name: "child2", Runner: childRunner2}})
+				Ω(err).Should(Equal(grouper.ErrMemberRejected{Reason: grouper.RejectedGroupPaused}))
+
+				client.Resume()
+				Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: childRunner1}))
+				Eventually(childRunner1.RunCallCount).Should(Equal(1))
+				Ω(childRunner2.RunCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("InsertAll", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("inserts every member", func() {
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child2", Runner: childRunner2},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+		})
+
+		It("inserts nothing when a name is already running", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+
+			err := client.InsertAll(grouper.Members{
+				{Name: "child2", Runner: childRunner2},
+				{Name: "child1", Runner: childRunner3},
+			})
+			Ω(err).Should(Equal(grouper.ErrDuplicateNames{DuplicateNames: []string{"child1"}}))
+
+			Consistently(childRunner2.RunCallCount).Should(BeZero())
+			Ω(childRunner3.RunCallCount()).Should(BeZero())
+		})
+
+		It("inserts nothing when the names are not unique", func() {
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "child1", Runner: childRunner2},
+			})
+			Ω(err).Should(Equal(grouper.ErrDuplicateNames{DuplicateNames: []string{"child1"}}))
+
+			Consistently(childRunner1.RunCallCount).Should(BeZero())
+			Ω(childRunner2.RunCallCount()).Should(BeZero())
+		})
+
+		It("starts members which do not fit once capacity is released", func() {
+			err := client.InsertAll(grouper.Members{
+				{Name: "child1", Runner: grouper.Weighted(childRunner1, 2)},
+				{Name: "child2", Runner: grouper.Weighted(childRunner2, 2)},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Consistently(childRunner2.RunCallCount).Should(BeZero())
+
+			childRunner1.TriggerExit(nil)
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+		})
+
+		It("is rejected once the group is closed", func() {
+			client.Close()
+
+			err := client.InsertAll(grouper.Members{{Name: "child1", Runner: childRunner1}})
+			Ω(err).Should(Equal(grouper.ErrMemberRejected{Reason: grouper.RejectedGroupClosed}))
+		})
+	})
+
+	Describe("Replace", func() {
+		var (
+			signal1  <-chan os.Signal
+			replaced chan error
+		)
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 1, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "blue", Runner: childRunner1}))
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+
+			replaced = make(chan error, 1)
+			go func() {
+				replaced <- client.Replace("blue", grouper.Member{Name: "green", Runner: childRunner2})
+			}()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("starts the new member beyond capacity, and stops the old member once it is ready", func() {
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			Consistently(signal1).ShouldNot(Receive())
+			Consistently(replaced).ShouldNot(Receive())
+
+			childRunner2.TriggerReady()
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(replaced).Should(Receive(BeNil()))
+		})
+
+		It("leaves the old member running when the new member fails to become ready", func() {
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			childRunner2.TriggerExit(errors.New("boom"))
+
+			var err error
+			Eventually(replaced).Should(Receive(&err))
+			Ω(err).Should(Equal(grouper.StartupError{
+				Member: grouper.Member{Name: "green", Runner: childRunner2},
+				Err:    errors.New("boom"),
+			}))
+
+			Consistently(signal1).ShouldNot(Receive())
+			_, ok := client.Get("blue")
+			Ω(ok).Should(BeTrue())
+		})
+
+		It("returns ErrMemberNotFound when the old member is not present", func() {
+			childRunner2.TriggerReady()
+			Eventually(replaced).Should(Receive())
+
+			err := client.Replace("red", grouper.Member{Name: "yellow", Runner: childRunner3})
+			Ω(err).Should(Equal(grouper.ErrMemberNotFound{Name: "red"}))
+			Ω(childRunner3.RunCallCount()).Should(BeZero())
+		})
+	})
+
+	Describe("when supervising a member panics", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				MaxCapacity:     3,
+				EventBufferSize: 3,
+				Metrics: grouper.MetricsHooks{
+					OnMemberReady: func(name string, startup time.Duration) {
+						if name == "child1" {
+							panic("misbehaving hook")
+						}
+					},
+				},
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("reports the panic as the member's exit, and continues supervising", func() {
+			exits := client.ExitListener()
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child1"))
+			Ω(exit.Err).Should(BeAssignableToTypeOf(ifrit.PanicError{}))
+			Ω(exit.Err.(ifrit.PanicError).Value).Should(Equal("misbehaving hook"))
+			Ω(exit.Err.(ifrit.PanicError).Stack).ShouldNot(BeEmpty())
+			Eventually(signal1).Should(Receive(Equal(os.Kill)))
+
+			childRunner2.TriggerReady()
+			Eventually(client.EntranceListenerFor("child2")).Should(Receive())
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+	})
+
+	Describe("Stats", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("counts members as they start and exit", func() {
+			exits := client.ExitListener()
+			Ω(client.Stats()).Should(Equal(grouper.GroupStats{}))
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+			Eventually(client.Stats).Should(Equal(grouper.GroupStats{Running: 3, Started: 3}))
+
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(nil)
+			Eventually(exits).Should(Receive())
+			Ω(client.Stats()).Should(Equal(grouper.GroupStats{Running: 2, Started: 3, ExitedCleanly: 1}))
+
+			childRunner2.TriggerExit(errors.New("boom"))
+			Eventually(exits).Should(Receive())
+			Ω(client.Stats()).Should(Equal(grouper.GroupStats{Running: 1, Started: 3, ExitedCleanly: 1, ExitedWithError: 1}))
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Stats).Should(Equal(grouper.GroupStats{Running: 2, Started: 4, ExitedCleanly: 1, ExitedWithError: 1}))
+		})
+	})
+
+	Describe("weighted capacity", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 3, 3)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("admits members while their total weight is within capacity", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: grouper.Weighted(childRunner1, 2)}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			Consistently(client.Inserter()).ShouldNot(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+		})
+
+		It("holds an insert which would exceed capacity until enough weight is released", func() {
+			exits := client.ExitListener()
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: grouper.Weighted(childRunner1, 2)}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child2", Runner: grouper.Weighted(childRunner2, 2)}))
+			Consistently(client.Inserter()).ShouldNot(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Ω(childRunner2.RunCallCount()).Should(BeZero())
+
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(nil)
+			Eventually(exits).Should(Receive())
+
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+		})
+
+		It("runs a member heavier than the capacity on its own", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child1", Runner: grouper.Weighted(childRunner1, 5)}))
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Consistently(client.Inserter()).ShouldNot(BeSent(grouper.Member{Name: "child2", Runner: childRunner2}))
+		})
+	})
+
+	Describe("MaxConcurrentStartups", func() {
+		BeforeEach(func() {
+			pool = grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+				MaxCapacity:           3,
+				EventBufferSize:       3,
+				MaxConcurrentStartups: 1,
+			})
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("starts the next member once the starting member is ready", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+			Consistently(client.Inserter()).ShouldNot(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Ω(childRunner2.RunCallCount()).Should(BeZero())
+
+			childRunner1.TriggerReady()
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+
+			childRunner2.TriggerReady()
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{Name: "child3", Runner: childRunner3}))
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+			childRunner3.TriggerReady()
+
+			Eventually(client.CountListener()).Should(Receive(Equal(3)))
+		})
+
+		It("starts the next member once the starting member exits", func() {
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child2", childRunner2}))
+
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+		})
+	})
+
+	Describe("rejected members", func() {
+		var exits <-chan grouper.ExitEvent
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 2, 5)
+			client = pool.Client()
+			poolProcess = ifrit.Envoke(pool)
+			exits = client.ExitListener()
+
+			Eventually(client.Inserter()).Should(BeSent(grouper.Member{"child1", childRunner1}))
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(func() ifrit.ProcessState {
+				childRunner1.EnsureExit()
+				childRunner2.EnsureExit()
+				childRunner3.EnsureExit()
+				return poolProcess.State()
+			}).Should(Equal(ifrit.StateExited))
+		})
+
+		It("emits a synthetic exit event for a duplicate name", func() {
+			duplicate := grouper.Member{Name: "child1", Runner: childRunner2}
+			Eventually(client.Inserter()).Should(BeSent(duplicate))
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member).Should(Equal(duplicate))
+			Ω(exit.Err).Should(Equal(grouper.ErrMemberRejected{Reason: grouper.RejectedDuplicateName}))
+			Consistently(exits).ShouldNot(Receive())
+
+			Ω(childRunner2.RunCallCount()).Should(BeZero())
+			Ω(poolProcess.State()).Should(Equal(ifrit.StateReady))
+		})
+
+		It("emits a synthetic exit event for a member waiting for capacity when the group is signaled", func() {
+			waiting := grouper.Member{Name: "child2", Runner: grouper.Weighted(childRunner2, 2)}
+			Eventually(client.Inserter()).Should(BeSent(waiting))
+			Consistently(childRunner2.RunCallCount).Should(BeZero())
+
+			poolProcess.Signal(os.Interrupt)
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member).Should(Equal(waiting))
+			Ω(exit.Err).Should(Equal(grouper.ErrMemberRejected{Reason: grouper.RejectedGroupStopped}))
+
+			childRunner1.TriggerExit(nil)
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Member.Name).Should(Equal("child1"))
+			Eventually(exits).Should(BeClosed())
+		})
+	})
+
 	Describe("Insert", func() {
 		var member1, member2, member3 grouper.Member
 
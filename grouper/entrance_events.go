@@ -1,17 +1,32 @@
 package grouper
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/tedsuo/ifrit"
 )
 
 /*
-An EntranceEvent occurs every time an invoked member becomes ready.
+An EntranceEvent occurs every time an invoked member becomes ready.  A member
+which exits before becoming ready also produces an entrance event, ahead of its
+exit event, so that every member is accounted for; such a member's Process is
+not ready.  A member which neither becomes ready nor exits produces no entrance
+event, and is reported by a dynamic group's OnMemberStuck watchdog instead.
+
+Replayed is set on events which occurred before the listener was attached, and
+were delivered from the event buffer.  Every replayed event is delivered before
+any live event.
 */
 type EntranceEvent struct {
-	Member  Member
-	Process ifrit.Process
+	Member   Member
+	Process  ifrit.Process
+	Replayed bool
+}
+
+// String returns "entrance: <name>".
+func (e EntranceEvent) String() string {
+	return fmt.Sprintf("entrance: %s", e.Member.Name)
 }
 
 type entranceEventChannel chan EntranceEvent
@@ -22,8 +37,13 @@ func newEntranceEventChannel(bufferSize int) entranceEventChannel {
 
 type entranceEventBroadcaster struct {
 	channels   []entranceEventChannel
-	buffer     slidingBuffer
+	filters    []func(EntranceEvent) bool
+	policies   []OverflowPolicy
+	dones      []<-chan struct{}
+	buffer     *slidingBuffer
 	bufferSize int
+	closed     bool
+	closing    chan struct{}
 	lock       *sync.Mutex
 }
 
@@ -32,34 +52,145 @@ func newEntranceEventBroadcaster(bufferSize int) *entranceEventBroadcaster {
 		channels:   make([]entranceEventChannel, 0),
 		buffer:     newSlidingBuffer(bufferSize),
 		bufferSize: bufferSize,
+		closing:    make(chan struct{}),
 		lock:       new(sync.Mutex),
 	}
 }
 
 func (b *entranceEventBroadcaster) Attach() entranceEventChannel {
+	return b.AttachFiltered(func(EntranceEvent) bool { return true })
+}
+
+func (b *entranceEventBroadcaster) AttachFiltered(filter func(EntranceEvent) bool) entranceEventChannel {
+	return b.AttachWithPolicy(filter, OverflowBlock)
+}
+
+func (b *entranceEventBroadcaster) AttachWithPolicy(filter func(EntranceEvent) bool, policy OverflowPolicy) entranceEventChannel {
+	return b.attach(filter, policy, nil)
+}
+
+// AttachUntil attaches a listener which is detached, and its channel closed,
+// once done is closed, even while a broadcast is blocked sending to it.
+func (b *entranceEventBroadcaster) AttachUntil(done <-chan struct{}) entranceEventChannel {
+	channel := b.attach(func(EntranceEvent) bool { return true }, OverflowBlock, done)
+	go func() {
+		select {
+		case <-done:
+			b.Detach(channel)
+		case <-b.closing:
+		}
+	}()
+	return channel
+}
+
+func (b *entranceEventBroadcaster) attach(filter func(EntranceEvent) bool, policy OverflowPolicy, done <-chan struct{}) entranceEventChannel {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
 	channel := newEntranceEventChannel(b.bufferSize)
 	b.buffer.Range(func(event interface{}) {
-		channel <- event.(EntranceEvent)
+		replayed := event.(EntranceEvent)
+		if !filter(replayed) {
+			return
+		}
+		replayed.Replayed = true
+		channel <- replayed
 	})
-	if b.channels != nil {
-		b.channels = append(b.channels, channel)
-	} else {
+	if b.closed {
 		close(channel)
+	} else {
+		b.channels = append(b.channels, channel)
+		b.filters = append(b.filters, filter)
+		b.policies = append(b.policies, policy)
+		b.dones = append(b.dones, done)
 	}
 	return channel
 }
 
+// Detach removes the listener, and closes its channel, unless it has already
+// been closed.  It waits for any broadcast which is blocked on the listener.
+func (b *entranceEventBroadcaster) Detach(channel <-chan EntranceEvent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for i := range b.channels {
+		if b.channels[i] != channel {
+			continue
+		}
+		close(b.channels[i])
+		b.channels = append(b.channels[:i], b.channels[i+1:]...)
+		b.filters = append(b.filters[:i], b.filters[i+1:]...)
+		b.policies = append(b.policies[:i], b.policies[i+1:]...)
+		b.dones = append(b.dones[:i], b.dones[i+1:]...)
+		return
+	}
+}
+
 func (b *entranceEventBroadcaster) Broadcast(entrance EntranceEvent) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	if b.closed {
+		return
+	}
+
 	b.buffer.Append(entrance)
 
-	for _, entranceChan := range b.channels {
-		entranceChan <- entrance
+	channels := b.channels[:0]
+	filters := b.filters[:0]
+	policies := b.policies[:0]
+	dones := b.dones[:0]
+	for i, entranceChan := range b.channels {
+		if b.filters[i](entrance) && !sendEntranceEvent(entranceChan, entrance, b.policies[i], b.dones[i]) {
+			close(entranceChan)
+			continue
+		}
+		channels = append(channels, entranceChan)
+		filters = append(filters, b.filters[i])
+		policies = append(policies, b.policies[i])
+		dones = append(dones, b.dones[i])
+	}
+	b.channels = channels
+	b.filters = filters
+	b.policies = policies
+	b.dones = dones
+}
+
+// sendEntranceEvent sends the event as the policy directs, and returns false if
+// the listener should be closed.  Only the broadcaster sends on the channel, so
+// once an event has been discarded there is room for the new one.  A blocked
+// send gives up once done is closed.
+func sendEntranceEvent(channel entranceEventChannel, entrance EntranceEvent, policy OverflowPolicy, done <-chan struct{}) bool {
+	switch policy {
+	case OverflowDropOldest:
+		select {
+		case channel <- entrance:
+			return true
+		default:
+		}
+		select {
+		case <-channel:
+		default:
+			return true
+		}
+		channel <- entrance
+		return true
+
+	case OverflowCloseListener:
+		select {
+		case channel <- entrance:
+			return true
+		default:
+			return false
+		}
+
+	default:
+		select {
+		case channel <- entrance:
+			return true
+		case <-done:
+			return false
+		}
 	}
 }
 
@@ -67,8 +198,17 @@ func (b *entranceEventBroadcaster) Close() {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	if b.closed {
+		return
+	}
+
 	for _, channel := range b.channels {
 		close(channel)
 	}
 	b.channels = nil
+	b.filters = nil
+	b.policies = nil
+	b.dones = nil
+	b.closed = true
+	close(b.closing)
 }
@@ -0,0 +1,21 @@
+package grouper_test
+
+import (
+	"fmt"
+
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EntranceEvent", func() {
+	Describe("String", func() {
+		It("reports the member's name", func() {
+			event := grouper.EntranceEvent{Member: grouper.Member{"child1", fake_runner.NewTestRunner()}}
+			Ω(event.String()).Should(Equal("entrance: child1"))
+			Ω(fmt.Sprintf("%v", event)).Should(Equal("entrance: child1"))
+		})
+	})
+})
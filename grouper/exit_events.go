@@ -3,16 +3,83 @@ package grouper
 import (
 	"fmt"
 	"sync"
+
+	"github.com/tedsuo/ifrit"
 )
 
 /*
 An ExitEvent occurs every time an invoked member exits.
+
+ExitCode is set when the member's error is an ifrit.ExitCoder, such as the
+*exec.ExitError of a member which wraps an OS process, and is nil for all other
+members.
+
+Replayed is set on events which occurred before the listener was attached, and
+were delivered from the event buffer.  Every replayed event is delivered before
+any live event.
 */
 type ExitEvent struct {
+	Member   Member
+	Err      error
+	ExitCode *int
+	Replayed bool
+}
+
+// String returns "exit: <name> (clean)", or "exit: <name> (err: <err>)".
+func (e ExitEvent) String() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit: %s (clean)", e.Member.Name)
+	}
+	return fmt.Sprintf("exit: %s (err: %s)", e.Member.Name, e.Err)
+}
+
+func newExitEvent(member Member, err error) ExitEvent {
+	return ExitEvent{
+		Member:   member,
+		Err:      err,
+		ExitCode: exitCode(err),
+	}
+}
+
+// newStartupExitEvent is newExitEvent for a member which exited before becoming
+// ready; a non-nil error is wrapped in a StartupError.
+func newStartupExitEvent(member Member, err error) ExitEvent {
+	if err != nil {
+		err = StartupError{Member: member, Err: err}
+	}
+	return newExitEvent(member, err)
+}
+
+/*
+StartupError wraps the error of a member which exited before becoming ready,
+distinguishing a member which failed to start from one which failed while
+running.  It is reported by parallel and dynamic groups.
+*/
+type StartupError struct {
 	Member Member
 	Err    error
 }
 
+func (e StartupError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s exited before becoming ready", e.Member.Name)
+	}
+	return fmt.Sprintf("%s failed before becoming ready: %s", e.Member.Name, e.Err)
+}
+
+// Unwrap returns the member's error.
+func (e StartupError) Unwrap() error {
+	return e.Err
+}
+
+func exitCode(err error) *int {
+	code, ok := ifrit.ExitCode(err)
+	if !ok {
+		return nil
+	}
+	return &code
+}
+
 type exitEventChannel chan ExitEvent
 
 func newExitEventChannel(bufferSize int) exitEventChannel {
@@ -21,8 +88,13 @@ func newExitEventChannel(bufferSize int) exitEventChannel {
 
 type exitEventBroadcaster struct {
 	channels   []exitEventChannel
-	buffer     slidingBuffer
+	filters    []func(ExitEvent) bool
+	policies   []OverflowPolicy
+	dones      []<-chan struct{}
+	buffer     *slidingBuffer
 	bufferSize int
+	closed     bool
+	closing    chan struct{}
 	lock       *sync.Mutex
 }
 
@@ -31,32 +103,145 @@ func newExitEventBroadcaster(bufferSize int) *exitEventBroadcaster {
 		channels:   make([]exitEventChannel, 0),
 		buffer:     newSlidingBuffer(bufferSize),
 		bufferSize: bufferSize,
+		closing:    make(chan struct{}),
 		lock:       new(sync.Mutex),
 	}
 }
 
 func (b *exitEventBroadcaster) Attach() exitEventChannel {
+	return b.AttachFiltered(func(ExitEvent) bool { return true })
+}
+
+func (b *exitEventBroadcaster) AttachFiltered(filter func(ExitEvent) bool) exitEventChannel {
+	return b.AttachWithPolicy(filter, OverflowBlock)
+}
+
+func (b *exitEventBroadcaster) AttachWithPolicy(filter func(ExitEvent) bool, policy OverflowPolicy) exitEventChannel {
+	return b.attach(filter, policy, nil)
+}
+
+// AttachUntil attaches a listener which is detached, and its channel closed,
+// once done is closed, even while a broadcast is blocked sending to it.
+func (b *exitEventBroadcaster) AttachUntil(done <-chan struct{}) exitEventChannel {
+	channel := b.attach(func(ExitEvent) bool { return true }, OverflowBlock, done)
+	go func() {
+		select {
+		case <-done:
+			b.Detach(channel)
+		case <-b.closing:
+		}
+	}()
+	return channel
+}
+
+func (b *exitEventBroadcaster) attach(filter func(ExitEvent) bool, policy OverflowPolicy, done <-chan struct{}) exitEventChannel {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
 	channel := newExitEventChannel(b.bufferSize)
 	b.buffer.Range(func(event interface{}) {
-		channel <- event.(ExitEvent)
+		replayed := event.(ExitEvent)
+		if !filter(replayed) {
+			return
+		}
+		replayed.Replayed = true
+		channel <- replayed
 	})
-	if b.channels != nil {
-		b.channels = append(b.channels, channel)
-	} else {
+	if b.closed {
 		close(channel)
+	} else {
+		b.channels = append(b.channels, channel)
+		b.filters = append(b.filters, filter)
+		b.policies = append(b.policies, policy)
+		b.dones = append(b.dones, done)
 	}
 	return channel
 }
 
+// Detach removes the listener, and closes its channel, unless it has already
+// been closed.  It waits for any broadcast which is blocked on the listener.
+func (b *exitEventBroadcaster) Detach(channel <-chan ExitEvent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for i := range b.channels {
+		if b.channels[i] != channel {
+			continue
+		}
+		close(b.channels[i])
+		b.channels = append(b.channels[:i], b.channels[i+1:]...)
+		b.filters = append(b.filters[:i], b.filters[i+1:]...)
+		b.policies = append(b.policies[:i], b.policies[i+1:]...)
+		b.dones = append(b.dones[:i], b.dones[i+1:]...)
+		return
+	}
+}
+
 func (b *exitEventBroadcaster) Broadcast(exit ExitEvent) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
+
+	if b.closed {
+		return
+	}
+
 	b.buffer.Append(exit)
-	for _, exitChan := range b.channels {
-		exitChan <- exit
+
+	channels := b.channels[:0]
+	filters := b.filters[:0]
+	policies := b.policies[:0]
+	dones := b.dones[:0]
+	for i, exitChan := range b.channels {
+		if b.filters[i](exit) && !sendExitEvent(exitChan, exit, b.policies[i], b.dones[i]) {
+			close(exitChan)
+			continue
+		}
+		channels = append(channels, exitChan)
+		filters = append(filters, b.filters[i])
+		policies = append(policies, b.policies[i])
+		dones = append(dones, b.dones[i])
+	}
+	b.channels = channels
+	b.filters = filters
+	b.policies = policies
+	b.dones = dones
+}
+
+// sendExitEvent sends the event as the policy directs, and returns false if
+// the listener should be closed.  Only the broadcaster sends on the channel, so
+// once an event has been discarded there is room for the new one.  A blocked
+// send gives up once done is closed.
+func sendExitEvent(channel exitEventChannel, exit ExitEvent, policy OverflowPolicy, done <-chan struct{}) bool {
+	switch policy {
+	case OverflowDropOldest:
+		select {
+		case channel <- exit:
+			return true
+		default:
+		}
+		select {
+		case <-channel:
+		default:
+			return true
+		}
+		channel <- exit
+		return true
+
+	case OverflowCloseListener:
+		select {
+		case channel <- exit:
+			return true
+		default:
+			return false
+		}
+
+	default:
+		select {
+		case channel <- exit:
+			return true
+		case <-done:
+			return false
+		}
 	}
 }
 
@@ -64,14 +249,68 @@ func (b *exitEventBroadcaster) Close() {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
+	if b.closed {
+		return
+	}
+
 	for _, channel := range b.channels {
 		close(channel)
 	}
 	b.channels = nil
+	b.filters = nil
+	b.policies = nil
+	b.dones = nil
+	b.closed = true
+	close(b.closing)
 }
 
 type ErrorTrace []ExitEvent
 
+func (trace ErrorTrace) memberNames() map[string]struct{} {
+	names := map[string]struct{}{}
+	for _, exitEvent := range trace {
+		names[exitEvent.Member.Name] = struct{}{}
+	}
+	return names
+}
+
+// without returns the trace less the exits of the given members.
+func (trace ErrorTrace) without(members Members) ErrorTrace {
+	names := map[string]struct{}{}
+	for _, member := range members {
+		names[member.Name] = struct{}{}
+	}
+
+	kept := ErrorTrace{}
+	for _, exitEvent := range trace {
+		if _, found := names[exitEvent.Member.Name]; !found {
+			kept = append(kept, exitEvent)
+		}
+	}
+	return kept
+}
+
+// requiredExited reports whether any member which is not Optional has exited.
+func (trace ErrorTrace) requiredExited() bool {
+	for _, exitEvent := range trace {
+		if !exitEvent.Member.Optional() {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredFailed reports whether any member which is not Optional has exited
+// with an error.
+func (trace ErrorTrace) requiredFailed() bool {
+	for _, exitEvent := range trace {
+		if exitEvent.Err != nil && !exitEvent.Member.Optional() {
+			return true
+		}
+	}
+	return false
+}
+
 func (trace ErrorTrace) Error() string {
 	msg := "Exit trace for group:\n"
 
@@ -85,3 +324,25 @@ func (trace ErrorTrace) Error() string {
 
 	return msg
 }
+
+/*
+ExitCode maps a trace to a single process exit code.  The mapping is applied to
+each member which exited with an error, and the highest resulting code is
+returned.  A trace with no errors maps to 0.
+*/
+func ExitCode(trace ErrorTrace, mapping func(ExitEvent) int) int {
+	code := 0
+
+	for _, exit := range trace {
+		if exit.Err == nil {
+			continue
+		}
+
+		memberCode := mapping(exit)
+		if memberCode > code {
+			code = memberCode
+		}
+	}
+
+	return code
+}
@@ -0,0 +1,168 @@
+package grouper_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+	"github.com/tedsuo/ifrit/test_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExitEvent", func() {
+	commandRunner := func(script string) ifrit.Runner {
+		return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			return exec.Command("sh", "-c", script).Run()
+		})
+	}
+
+	Describe("ExitCode", func() {
+		var (
+			pool        grouper.DynamicGroup
+			client      grouper.DynamicClient
+			poolProcess ifrit.Process
+			exits       <-chan grouper.ExitEvent
+		)
+
+		BeforeEach(func() {
+			pool = grouper.NewDynamic(nil, 2, 2)
+			client = pool.Client()
+			poolProcess = ifrit.Invoke(pool)
+			exits = client.ExitListener()
+		})
+
+		AfterEach(func() {
+			poolProcess.Signal(os.Kill)
+			Eventually(poolProcess.Wait()).Should(Receive())
+		})
+
+		It("is set when a process-backed member exits with a non-zero code", func() {
+			client.Inserter() <- grouper.Member{Name: "command", Runner: commandRunner("exit 3")}
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.Err).Should(BeAssignableToTypeOf(&exec.ExitError{}))
+			Ω(exit.ExitCode).ShouldNot(BeNil())
+			Ω(*exit.ExitCode).Should(Equal(3))
+		})
+
+		It("is nil for members which do not wrap a process", func() {
+			runner := fake_runner.NewTestRunner()
+			client.Inserter() <- grouper.Member{"runner", runner}
+			runner.TriggerReady()
+			runner.TriggerExit(nil)
+
+			var exit grouper.ExitEvent
+			Eventually(exits).Should(Receive(&exit))
+			Ω(exit.ExitCode).Should(BeNil())
+		})
+
+		It("is set in the error trace of a static group", func() {
+			group := ifrit.Background(grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "command", Runner: commandRunner("sleep 0.1; exit 7")},
+				{"recorder", test_helpers.NewSignalRecorder()},
+			}))
+
+			var err error
+			Eventually(group.Wait()).Should(Receive(&err))
+			errTrace := err.(grouper.ErrorTrace)
+			Ω(errTrace).Should(HaveLen(2))
+			Ω(*errTrace[0].ExitCode).Should(Equal(7))
+			Ω(errTrace[1].ExitCode).Should(BeNil())
+		})
+	})
+
+	Describe("String", func() {
+		It("reports a clean exit", func() {
+			event := grouper.ExitEvent{Member: grouper.Member{"child1", fake_runner.NewTestRunner()}}
+			Ω(event.String()).Should(Equal("exit: child1 (clean)"))
+			Ω(fmt.Sprintf("%v", event)).Should(Equal("exit: child1 (clean)"))
+		})
+
+		It("reports the exit error", func() {
+			event := grouper.ExitEvent{Member: grouper.Member{"child1", fake_runner.NewTestRunner()}, Err: errors.New("boom")}
+			Ω(event.String()).Should(Equal("exit: child1 (err: boom)"))
+			Ω(fmt.Sprintf("%s", event)).Should(Equal("exit: child1 (err: boom)"))
+		})
+	})
+
+	Describe("grouper.ExitCode", func() {
+		var mapping func(grouper.ExitEvent) int
+
+		BeforeEach(func() {
+			mapping = func(exit grouper.ExitEvent) int {
+				if exit.ExitCode != nil {
+					return *exit.ExitCode
+				}
+				if exit.Err.Error() == "config" {
+					return 78
+				}
+				return 1
+			}
+		})
+
+		It("returns 0 for a clean trace", func() {
+			Ω(grouper.ExitCode(nil, mapping)).Should(Equal(0))
+			Ω(grouper.ExitCode(grouper.ErrorTrace{
+				{Member: grouper.Member{"child1", nil}},
+				{Member: grouper.Member{"child2", nil}},
+			}, mapping)).Should(Equal(0))
+		})
+
+		It("returns the highest code mapped from the failed members", func() {
+			three := 3
+			trace := grouper.ErrorTrace{
+				{Member: grouper.Member{"child1", nil}},
+				{Member: grouper.Member{"child2", nil}, Err: errors.New("boom")},
+				{Member: grouper.Member{"child3", nil}, Err: &exec.ExitError{}, ExitCode: &three},
+			}
+			Ω(grouper.ExitCode(trace, mapping)).Should(Equal(3))
+
+			trace = append(trace, grouper.ExitEvent{Member: grouper.Member{"child4", nil}, Err: errors.New("config")})
+			Ω(grouper.ExitCode(trace, mapping)).Should(Equal(78))
+		})
+	})
+
+	Describe("listening while the group shuts down", func() {
+		It("never panics, and closes every listener", func() {
+			pool := grouper.NewDynamic(nil, 50, 5)
+			client := pool.Client()
+			poolProcess := ifrit.Invoke(pool)
+
+			quick := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				return nil
+			})
+
+			var listeners sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				listeners.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer listeners.Done()
+					for j := 0; j < 20; j++ {
+						for range client.ExitListener() {
+						}
+					}
+				}()
+			}
+
+			for i := 0; i < 50; i++ {
+				client.Inserter() <- grouper.Member{Name: fmt.Sprintf("member-%d", i), Runner: quick}
+			}
+			client.Close()
+
+			Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+			listeners.Wait()
+			Eventually(client.ExitListener()).Should(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package grouper
+
+/*
+An ExitMode decides which member exits cause a dynamic or ordered group to shut
+down, by propagating its termination signal to the remaining members.  A group
+with no termination signal never shuts down on a member's exit, and the exit of
+an Optional member never shuts a dynamic group down.
+*/
+type ExitMode int
+
+const (
+	// StopOnAnyExit shuts the group down when any member exits, cleanly or
+	// not.  This is the default.
+	StopOnAnyExit ExitMode = iota
+
+	// StopOnErrorExit shuts the group down only when a member exits with an
+	// error, so that members may finish their work and leave cleanly.
+	StopOnErrorExit
+
+	// StopNever leaves the group running whatever its members' exits.  The
+	// group shuts down only when it is signaled, or exits once it is closed and
+	// every member has exited.
+	StopNever
+)
+
+func (m ExitMode) stops(err error) bool {
+	switch m {
+	case StopOnErrorExit:
+		return err != nil
+	case StopNever:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,37 @@
+package grouper
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+GroupConfigInfo describes how a group was constructed.  It is read-only
+metadata, meant for assertions in tests and for status pages.
+
+PoolSize and EventBufferSize are only set for a dynamic group, and PoolSize for
+a work queue.  Ordered is set
+for groups which start their members in a fixed sequence, waiting for each to
+become ready before starting the next.
+*/
+type GroupConfigInfo struct {
+	TerminationSignal os.Signal
+	PoolSize          int
+	EventBufferSize   int
+	Ordered           bool
+}
+
+/*
+ConfigOf returns the configuration of a runner created by one of the group
+constructors in this package.  It returns false for any other runner.
+*/
+func ConfigOf(runner ifrit.Runner) (GroupConfigInfo, bool) {
+	group, ok := runner.(interface {
+		GroupConfig() GroupConfigInfo
+	})
+	if !ok {
+		return GroupConfigInfo{}, false
+	}
+	return group.GroupConfig(), true
+}
@@ -0,0 +1,60 @@
+package grouper_test
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigOf", func() {
+	var members grouper.Members
+
+	BeforeEach(func() {
+		members = grouper.Members{{Name: "child1", Runner: fake_runner.NewTestRunner()}}
+	})
+
+	It("reports the configuration of a parallel group", func() {
+		config, ok := grouper.ConfigOf(grouper.NewParallel(syscall.SIGUSR1, members))
+		Ω(ok).Should(BeTrue())
+		Ω(config).Should(Equal(grouper.GroupConfigInfo{TerminationSignal: syscall.SIGUSR1}))
+	})
+
+	It("reports the configuration of an ordered group", func() {
+		config, ok := grouper.ConfigOf(grouper.NewOrdered(os.Interrupt, members))
+		Ω(ok).Should(BeTrue())
+		Ω(config).Should(Equal(grouper.GroupConfigInfo{TerminationSignal: os.Interrupt, Ordered: true}))
+	})
+
+	It("reports the configuration of a staggered group", func() {
+		config, ok := grouper.ConfigOf(grouper.NewStaggered(nil, members, time.Second))
+		Ω(ok).Should(BeTrue())
+		Ω(config).Should(Equal(grouper.GroupConfigInfo{TerminationSignal: os.Interrupt}))
+	})
+
+	It("reports the configuration of a dynamic group", func() {
+		pool := grouper.NewDynamic(syscall.SIGUSR2, 3, 5)
+		Ω(pool.GroupConfig()).Should(Equal(grouper.GroupConfigInfo{
+			TerminationSignal: syscall.SIGUSR2,
+			PoolSize:          3,
+			EventBufferSize:   5,
+		}))
+
+		config, ok := grouper.ConfigOf(pool)
+		Ω(ok).Should(BeTrue())
+		Ω(config).Should(Equal(pool.GroupConfig()))
+	})
+
+	It("returns false for a runner which is not a group", func() {
+		_, ok := grouper.ConfigOf(ifrit.RunFunc(func(<-chan os.Signal, chan<- struct{}) error {
+			return nil
+		}))
+		Ω(ok).Should(BeFalse())
+	})
+})
@@ -0,0 +1,83 @@
+package grouper
+
+import (
+	"fmt"
+	"sync"
+)
+
+/*
+A GroupReadyEvent occurs once a dynamic group has seen as many members become
+ready as were expected by DynamicClient.ExpectMembers.  Members is the number of
+members which had become ready, counting those which have since exited.
+*/
+type GroupReadyEvent struct {
+	Members int
+}
+
+// String returns "group ready: <members> members".
+func (e GroupReadyEvent) String() string {
+	return fmt.Sprintf("group ready: %d members", e.Members)
+}
+
+/*
+groupReadyBroadcaster delivers a single GroupReadyEvent to every listener,
+including listeners attached after it has occurred.
+*/
+type groupReadyBroadcaster struct {
+	channels []chan GroupReadyEvent
+	event    *GroupReadyEvent
+	closed   bool
+	lock     *sync.Mutex
+}
+
+func newGroupReadyBroadcaster() *groupReadyBroadcaster {
+	return &groupReadyBroadcaster{
+		channels: make([]chan GroupReadyEvent, 0),
+		lock:     new(sync.Mutex),
+	}
+}
+
+func (b *groupReadyBroadcaster) Attach() <-chan GroupReadyEvent {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	channel := make(chan GroupReadyEvent, 1)
+	if b.event != nil {
+		channel <- *b.event
+	}
+	if b.closed {
+		close(channel)
+	} else {
+		b.channels = append(b.channels, channel)
+	}
+	return channel
+}
+
+func (b *groupReadyBroadcaster) Broadcast(event GroupReadyEvent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed || b.event != nil {
+		return
+	}
+
+	b.event = &event
+	for _, channel := range b.channels {
+		channel <- event
+	}
+}
+
+func (b *groupReadyBroadcaster) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, channel := range b.channels {
+		close(channel)
+	}
+	b.channels = nil
+	b.closed = true
+}
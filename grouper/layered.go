@@ -0,0 +1,104 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// The backoff between restarts of a failing layer grows from
+// LayerRestartInitialDelay, doubling with each consecutive failure, up to
+// LayerRestartMaxDelay.
+const (
+	LayerRestartInitialDelay = 100 * time.Millisecond
+	LayerRestartMaxDelay     = 10 * time.Second
+)
+
+/*
+NewLayered starts each layer as a parallel group, and starts the layers in
+order, each layer starting when the previous layer is ready.  On shutdown, it
+will shut the layers down in reverse order.
+
+If restartLayerOnFailure is true, a layer which exits with an error is restarted
+on its own, leaving the other layers running.  Consecutive restarts of a layer
+are delayed by an exponential backoff, from LayerRestartInitialDelay up to
+LayerRestartMaxDelay, which is reset once a layer has run for
+ifrit.DefaultHealthyDuration.  Otherwise a failing layer causes the whole group
+to shut down, as an ordered group would.
+
+Member names must be unique across all layers.
+*/
+func NewLayered(terminationSignal os.Signal, layers [][]Member, restartLayerOnFailure bool) ifrit.Runner {
+	return &layeredGroup{
+		terminationSignal:     terminationSignal,
+		layers:                layers,
+		restartLayerOnFailure: restartLayerOnFailure,
+	}
+}
+
+/*
+NewStaged starts each stage's members together, and waits for all of them to
+become ready before starting the next stage.  On shutdown, the stages are shut
+down in reverse order.  It sits between an ordered group, which starts one
+member at a time, and a parallel group, which starts every member at once.  A
+failing member causes the whole group to shut down.
+
+Member names must be unique across all stages.  NewStaged is NewLayered, without
+restarting failed stages.
+*/
+func NewStaged(terminationSignal os.Signal, stages [][]Member) ifrit.Runner {
+	return NewLayered(terminationSignal, stages, false)
+}
+
+type layeredGroup struct {
+	terminationSignal     os.Signal
+	layers                [][]Member
+	restartLayerOnFailure bool
+}
+
+// GroupConfig reports the group's termination signal, and that it is ordered.
+func (g *layeredGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal, Ordered: true}
+}
+
+func (g *layeredGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := g.validate()
+	if err != nil {
+		return err
+	}
+
+	layerMembers := make(Members, 0, len(g.layers))
+	for i, layer := range g.layers {
+		layerMembers = append(layerMembers, Member{
+			Name:   fmt.Sprintf("layer-%d", i),
+			Runner: g.layerRunner(layer),
+		})
+	}
+
+	return NewOrdered(g.terminationSignal, layerMembers).Run(signals, ready)
+}
+
+func (g *layeredGroup) validate() error {
+	members := Members{}
+	for _, layer := range g.layers {
+		members = members.Append(layer)
+	}
+	return members.Validate()
+}
+
+func (g *layeredGroup) layerRunner(layer Members) ifrit.Runner {
+	if !g.restartLayerOnFailure {
+		return NewParallel(g.terminationSignal, layer)
+	}
+
+	return ifrit.RestartMonitor{
+		Factory: func() ifrit.Runner {
+			return NewParallel(g.terminationSignal, layer)
+		},
+		Backoff:         ifrit.ExponentialBackoff(LayerRestartInitialDelay, LayerRestartMaxDelay, 2),
+		Healthy:         ifrit.DefaultHealthyDuration,
+		StopOnCleanExit: true,
+	}
+}
@@ -0,0 +1,248 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Layered Group", func() {
+	var (
+		groupProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+
+		flakyRuns int32
+		flakyFail chan error
+		flaky     ifrit.Runner
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+
+		atomic.StoreInt32(&flakyRuns, 0)
+		flakyFail = make(chan error)
+		flaky = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			atomic.AddInt32(&flakyRuns, 1)
+			close(ready)
+			select {
+			case err := <-flakyFail:
+				return err
+			case <-signals:
+				return nil
+			}
+		})
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+
+		groupProcess.Signal(os.Kill)
+		Eventually(groupProcess.Wait()).Should(Receive())
+	})
+
+	runFlakyRuns := func() int32 {
+		return atomic.LoadInt32(&flakyRuns)
+	}
+
+	Context("when restarting layers on failure", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewLayered(os.Interrupt, [][]grouper.Member{
+				{{"child1", childRunner1}, {"child2", childRunner2}},
+				{{"flaky", flaky}},
+			}, true))
+		})
+
+		It("starts each layer once the previous layer is ready", func() {
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+
+			childRunner1.TriggerReady()
+			Consistently(runFlakyRuns, Δ).Should(BeZero())
+
+			childRunner2.TriggerReady()
+			Eventually(runFlakyRuns).Should(Equal(int32(1)))
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("restarts only the failing layer", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			flakyFail <- errors.New("boom")
+
+			Eventually(runFlakyRuns).Should(Equal(int32(2)))
+			Consistently(signal1, Δ).ShouldNot(Receive())
+			Consistently(signal2, Δ).ShouldNot(Receive())
+			Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a restarted layer keeps failing", func() {
+		BeforeEach(func() {
+			failing := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				atomic.AddInt32(&flakyRuns, 1)
+				return errors.New("boom")
+			})
+			groupProcess = ifrit.Background(grouper.NewLayered(os.Interrupt, [][]grouper.Member{
+				{{Name: "failing", Runner: failing}},
+			}, true))
+		})
+
+		It("backs off between restarts", func() {
+			Eventually(runFlakyRuns).Should(Equal(int32(2)))
+			Consistently(runFlakyRuns, 2*grouper.LayerRestartInitialDelay).Should(BeNumerically("<=", 3))
+		})
+	})
+
+	Context("when not restarting layers on failure", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewLayered(os.Interrupt, [][]grouper.Member{
+				{{"child1", childRunner1}},
+				{{"flaky", flaky}},
+			}, false))
+		})
+
+		It("shuts down every layer", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			flakyFail <- errors.New("boom")
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(HaveOccurred()))
+			Ω(runFlakyRuns()).Should(Equal(int32(1)))
+		})
+	})
+
+	Context("when names collide across layers", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewLayered(os.Interrupt, [][]grouper.Member{
+				{{"child1", childRunner1}},
+				{{"child1", childRunner2}},
+			}, true))
+		})
+
+		It("returns an error without starting anything", func() {
+			Eventually(groupProcess.Wait()).Should(Receive(Equal(grouper.ErrDuplicateNames{[]string{"child1"}})))
+			Ω(childRunner1.RunCallCount()).Should(BeZero())
+		})
+	})
+})
+
+var _ = Describe("Staged Group", func() {
+	var (
+		groupProcess ifrit.Process
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		events       chan string
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	member := func(name string) grouper.Member {
+		return grouper.Member{
+			Name: name,
+			Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				events <- "start " + name
+				close(ready)
+				<-signals
+				events <- "stop " + name
+				return nil
+			}),
+		}
+	}
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		events = make(chan string, 10)
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+
+		groupProcess.Signal(os.Kill)
+		Eventually(groupProcess.Wait()).Should(Receive())
+	})
+
+	Context("with three stages", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewStaged(os.Interrupt, [][]grouper.Member{
+				{{"child1", childRunner1}, {"child2", childRunner2}},
+				{member("b1"), member("b2")},
+				{member("c")},
+			}))
+		})
+
+		It("starts each stage together, once every member of the previous stage is ready", func() {
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+
+			childRunner1.TriggerReady()
+			Consistently(events, Δ).ShouldNot(Receive())
+
+			childRunner2.TriggerReady()
+			Eventually(events).Should(Receive(BeElementOf("start b1", "start b2")))
+			Eventually(events).Should(Receive(BeElementOf("start b1", "start b2")))
+			Eventually(events).Should(Receive(Equal("start c")))
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("shuts the stages down in reverse order", func() {
+			signal1 := childRunner1.WaitForCall()
+			signal2 := childRunner2.WaitForCall()
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			for i := 0; i < 3; i++ {
+				Ω(events).Should(Receive())
+			}
+
+			groupProcess.Signal(os.Interrupt)
+
+			Eventually(events).Should(Receive(Equal("stop c")))
+			Eventually(events).Should(Receive(BeElementOf("stop b1", "stop b2")))
+			Eventually(events).Should(Receive(BeElementOf("stop b1", "stop b2")))
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when names collide across stages", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewStaged(os.Interrupt, [][]grouper.Member{
+				{{"child1", childRunner1}},
+				{{"child1", childRunner2}},
+			}))
+		})
+
+		It("returns an error without starting anything", func() {
+			Eventually(groupProcess.Wait()).Should(Receive(Equal(grouper.ErrDuplicateNames{[]string{"child1"}})))
+			Ω(childRunner1.RunCallCount()).Should(BeZero())
+		})
+	})
+})
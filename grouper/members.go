@@ -8,27 +8,221 @@ import (
 
 /*
 A Member associates a unique name with a Runner.
+
+A member's Runner may be given optional properties, which groups that support
+them honour, by wrapping it with Weighted, Optional or Labeled.  The wrapped Runner
+runs the original Runner, so that Member keeps only a name and a Runner, and
+remains comparable.
 */
 type Member struct {
 	Name string
 	ifrit.Runner
 }
 
+/*
+Weighted returns the runner, weighted for a dynamic group to account for the
+member against its capacity.  A weight of zero or less counts as one.
+*/
+func Weighted(runner ifrit.Runner, weight int) ifrit.Runner {
+	options := optionsOf(runner)
+	options.weight = weight
+	return options
+}
+
+/*
+Optional returns the runner, marked as optional: its member may exit, even
+with an error, without stopping the group.  A dynamic group does not propagate
+its termination signal, and a parallel group neither stops nor fails.  Its
+exit is still reported.
+*/
+func Optional(runner ifrit.Runner) ifrit.Runner {
+	options := optionsOf(runner)
+	options.optional = true
+	return options
+}
+
+/*
+Labeled returns the runner with labels: key/value annotations, such as
+team=payments, by which the members of a dynamic group may be selected with
+DynamicClient.Select.  The labels are added to any the runner already has, and
+are copied, so that changing the map afterwards has no effect.
+*/
+func Labeled(runner ifrit.Runner, labels map[string]string) ifrit.Runner {
+	options := optionsOf(runner)
+	merged := make(map[string]string, len(options.labels)+len(labels))
+	for key, value := range options.labels {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	options.labels = merged
+	return options
+}
+
+// memberOptions wraps a member's Runner with the properties it has been given.
+// It is always held by pointer, so that a Member remains comparable.
+type memberOptions struct {
+	ifrit.Runner
+	weight   int
+	optional bool
+	labels   map[string]string
+}
+
+// optionsOf returns a copy of the options the runner has been given, wrapping
+// the original runner, so that options may be combined without modifying a
+// runner which is already in use.
+func optionsOf(runner ifrit.Runner) *memberOptions {
+	if options, ok := runner.(*memberOptions); ok {
+		copied := *options
+		return &copied
+	}
+	return &memberOptions{Runner: runner}
+}
+
+// runner returns the member's original Runner, without its options.
+func (m Member) runner() ifrit.Runner {
+	if options, ok := m.Runner.(*memberOptions); ok {
+		return options.Runner
+	}
+	return m.Runner
+}
+
+// Optional reports whether the member's Runner is Optional.
+func (m Member) Optional() bool {
+	options, ok := m.Runner.(*memberOptions)
+	return ok && options.optional
+}
+
+// Labels returns a copy of the labels the member's Runner was given by Labeled.
+func (m Member) Labels() map[string]string {
+	labels := map[string]string{}
+	for key, value := range m.labels() {
+		labels[key] = value
+	}
+	return labels
+}
+
+func (m Member) labels() map[string]string {
+	options, ok := m.Runner.(*memberOptions)
+	if !ok {
+		return nil
+	}
+	return options.labels
+}
+
+func (m Member) weight() int {
+	options, ok := m.Runner.(*memberOptions)
+	if !ok || options.weight <= 0 {
+		return 1
+	}
+	return options.weight
+}
+
+// String returns the member's name.
+func (m Member) String() string {
+	return m.Name
+}
+
 /*
 Members are treated as an ordered list. Member names must be unique.
 */
 type Members []Member
 
 /*
-Validate checks that all member names in the list are unique. It returns an
-error of type ErrDuplicateNames if duplicates are detected.
+Add returns a new list containing the members followed by a new member with the
+given name and runner.  The original list is not modified.
+*/
+func (m Members) Add(name string, runner ifrit.Runner) Members {
+	return m.Append(Members{{Name: name, Runner: runner}})
+}
+
+/*
+RunnersToMembers names each runner after the prefix and its index, as in
+"prefix-0", "prefix-1", and so on.  The names are unique within the list, and
+depend only on the prefix and the order of the runners.
+*/
+func RunnersToMembers(prefix string, runners []ifrit.Runner) Members {
+	members := make(Members, 0, len(runners))
+	for i, runner := range runners {
+		members = append(members, Member{Name: fmt.Sprintf("%s-%d", prefix, i), Runner: runner})
+	}
+	return members
+}
+
+/*
+Append returns a new list containing the members followed by the other members,
+preserving the order of both lists.  The original lists are not modified.
+*/
+func (m Members) Append(other Members) Members {
+	members := make(Members, 0, len(m)+len(other))
+	members = append(members, m...)
+	return append(members, other...)
+}
+
+/*
+Filter returns a new list of the members for which keep returns true, in their
+original order.  The original list is not modified.  The result may be empty,
+which is a valid list for any group.
+*/
+func (m Members) Filter(keep func(Member) bool) Members {
+	members := make(Members, 0, len(m))
+	for _, member := range m {
+		if keep(member) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+/*
+Map returns a new list of the result of calling fn with each member, in their
+original order.  Use Map to wrap each member's Runner, for example with
+middleware.  A Runner replaced by fn does not keep the options, such as
+Optional, given to the original.  The original list is not modified.
+*/
+func (m Members) Map(fn func(Member) Member) Members {
+	members := make(Members, 0, len(m))
+	for _, member := range m {
+		members = append(members, fn(member))
+	}
+	return members
+}
+
+/*
+Merge appends the other members, as Append does, but returns an error of type
+ErrDuplicateNames if the resulting list contains duplicate names.
+*/
+func (m Members) Merge(other Members) (Members, error) {
+	members := m.Append(other)
+
+	err := members.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+/*
+Validate checks that every member has a name and a Runner, and that all member
+names in the list are unique. It returns an error of type ErrInvalidMember for
+the first member without a name or Runner, or an error of type
+ErrDuplicateNames if duplicates are detected.
 */
 func (m Members) Validate() error {
 	foundNames := map[string]struct{}{}
 	foundToken := struct{}{}
 	duplicateNames := []string{}
 
-	for _, member := range m {
+	for i, member := range m {
+		if member.Name == "" {
+			return ErrInvalidMember{Index: i, Reason: "empty name"}
+		}
+		if member.runner() == nil {
+			return ErrInvalidMember{Index: i, Name: member.Name, Reason: "nil runner"}
+		}
+
 		_, present := foundNames[member.Name]
 		if present {
 			duplicateNames = append(duplicateNames, member.Name)
@@ -43,6 +237,23 @@ func (m Members) Validate() error {
 	return nil
 }
 
+/*
+ErrInvalidMember is returned to indicate a member which can not be run. Index is
+the position of the member in the list, and Reason describes what was wrong.
+*/
+type ErrInvalidMember struct {
+	Index  int
+	Name   string
+	Reason string
+}
+
+func (e ErrInvalidMember) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("Invalid member at index %d: %s", e.Index, e.Reason)
+	}
+	return fmt.Sprintf("Invalid member %s at index %d: %s", e.Name, e.Index, e.Reason)
+}
+
 /*
 ErrDuplicateNames is returned to indicate two or more members with the same name
 were detected. Because more than one duplicate name may be detected in a single
@@ -1,6 +1,11 @@
 package grouper_test
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
 	"github.com/tedsuo/ifrit/grouper"
 
 	. "github.com/onsi/ginkgo"
@@ -9,27 +14,35 @@ import (
 
 var _ = Describe("Members", func() {
 	Describe("Validate", func() {
-		type duplicateNameExample struct {
+		type validateExample struct {
 			memberNames   []string
+			nilRunnerAt   int
 			expectedError error
 		}
 
-		var testInput []duplicateNameExample
+		var testInput []validateExample
 
 		BeforeEach(func() {
-			testInput = []duplicateNameExample{
-				{[]string{"foo", "foo"}, grouper.ErrDuplicateNames{[]string{"foo"}}},
-				{[]string{"foo", "bar", "foo", "bar", "none"}, grouper.ErrDuplicateNames{[]string{"foo", "bar"}}},
-				{[]string{"foo", "bar"}, nil},
-				{[]string{"f", "foo", "fooo"}, nil},
+			testInput = []validateExample{
+				{[]string{"foo", "foo"}, -1, grouper.ErrDuplicateNames{[]string{"foo"}}},
+				{[]string{"foo", "bar", "foo", "bar", "none"}, -1, grouper.ErrDuplicateNames{[]string{"foo", "bar"}}},
+				{[]string{"foo", "bar"}, -1, nil},
+				{[]string{"f", "foo", "fooo"}, -1, nil},
+				{[]string{"foo", "", "bar"}, -1, grouper.ErrInvalidMember{Index: 1, Reason: "empty name"}},
+				{[]string{"foo", "bar"}, 1, grouper.ErrInvalidMember{Index: 1, Name: "bar", Reason: "nil runner"}},
+				{[]string{"foo", "foo", ""}, -1, grouper.ErrInvalidMember{Index: 2, Reason: "empty name"}},
 			}
 		})
 
-		It("returns any found duplicate names", func() {
+		It("returns any invalid members or duplicate names", func() {
 			for _, example := range testInput {
 				members := grouper.Members{}
-				for _, name := range example.memberNames {
-					members = append(members, grouper.Member{Name: name, Runner: nil})
+				for i, name := range example.memberNames {
+					var runner ifrit.Runner = fake_runner.NewTestRunner()
+					if i == example.nilRunnerAt {
+						runner = nil
+					}
+					members = append(members, grouper.Member{Name: name, Runner: runner})
 				}
 				err := members.Validate()
 				if err == nil {
@@ -41,4 +54,146 @@ var _ = Describe("Members", func() {
 			}
 		})
 	})
+
+	Describe("builder helpers", func() {
+		var (
+			runner1 *fake_runner.TestRunner
+			runner2 *fake_runner.TestRunner
+			runner3 *fake_runner.TestRunner
+			members grouper.Members
+		)
+
+		BeforeEach(func() {
+			runner1 = fake_runner.NewTestRunner()
+			runner2 = fake_runner.NewTestRunner()
+			runner3 = fake_runner.NewTestRunner()
+			members = grouper.Members{{"child1", runner1}}
+		})
+
+		Describe("Add", func() {
+			It("returns a new list with the member at the end", func() {
+				added := members.Add("child2", runner2)
+				Ω(added).Should(Equal(grouper.Members{{"child1", runner1}, {"child2", runner2}}))
+				Ω(members).Should(HaveLen(1))
+			})
+		})
+
+		Describe("Append", func() {
+			It("preserves the order of both lists", func() {
+				appended := members.Append(grouper.Members{{"child3", runner3}, {"child2", runner2}})
+				Ω(appended).Should(Equal(grouper.Members{
+					{"child1", runner1},
+					{"child3", runner3},
+					{"child2", runner2},
+				}))
+			})
+
+			It("does not modify the original list", func() {
+				withSpare := make(grouper.Members, 1, 2)
+				copy(withSpare, members)
+
+				withSpare.Append(grouper.Members{{"child2", runner2}})
+				Ω(withSpare[:2][1]).Should(Equal(grouper.Member{}))
+			})
+		})
+
+		Describe("Merge", func() {
+			It("returns the combined list when names are unique", func() {
+				merged, err := members.Merge(grouper.Members{{"child2", runner2}})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(merged).Should(Equal(grouper.Members{{"child1", runner1}, {"child2", runner2}}))
+			})
+
+			It("returns an error when names collide", func() {
+				merged, err := members.Merge(grouper.Members{{"child2", runner2}, {"child1", runner3}})
+				Ω(err).Should(Equal(grouper.ErrDuplicateNames{[]string{"child1"}}))
+				Ω(merged).Should(BeNil())
+			})
+		})
+	})
+
+	Describe("Member.String", func() {
+		It("returns the member's name", func() {
+			member := grouper.Member{"child1", fake_runner.NewTestRunner()}
+			Ω(member.String()).Should(Equal("child1"))
+			Ω(fmt.Sprintf("%v", member)).Should(Equal("child1"))
+		})
+	})
+
+	Describe("Filter and Map", func() {
+		var (
+			runner1 *fake_runner.TestRunner
+			runner2 *fake_runner.TestRunner
+			members grouper.Members
+		)
+
+		BeforeEach(func() {
+			runner1 = fake_runner.NewTestRunner()
+			runner2 = fake_runner.NewTestRunner()
+			members = grouper.Members{
+				{Name: "web", Runner: runner1},
+				{Name: "worker", Runner: grouper.Optional(runner2)},
+			}
+		})
+
+		It("filters the members without modifying the original list", func() {
+			required := members.Filter(func(member grouper.Member) bool {
+				return !member.Optional()
+			})
+			Ω(required).Should(Equal(grouper.Members{{Name: "web", Runner: runner1}}))
+			Ω(members).Should(HaveLen(2))
+		})
+
+		It("maps the members without modifying the original list", func() {
+			wrapper := fake_runner.NewTestRunner()
+			wrapped := members.Map(func(member grouper.Member) grouper.Member {
+				member.Name = "wrapped-" + member.Name
+				member.Runner = wrapper
+				return member
+			})
+			Ω(wrapped).Should(Equal(grouper.Members{
+				{Name: "wrapped-web", Runner: wrapper},
+				{Name: "wrapped-worker", Runner: wrapper},
+			}))
+			Ω(members[0]).Should(Equal(grouper.Member{Name: "web", Runner: runner1}))
+		})
+
+		It("filters to an empty list, which runs as a group that is ready at once", func() {
+			none := members.Filter(func(grouper.Member) bool { return false })
+			Ω(none).Should(BeEmpty())
+			Ω(none.Validate()).Should(Succeed())
+
+			process := ifrit.Background(grouper.NewParallel(os.Interrupt, none))
+			Eventually(process.Ready()).Should(BeClosed())
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Describe("RunnersToMembers", func() {
+		It("names the runners after the prefix and their index", func() {
+			runner1 := fake_runner.NewTestRunner()
+			runner2 := fake_runner.NewTestRunner()
+			runner3 := fake_runner.NewTestRunner()
+
+			members := grouper.RunnersToMembers("worker", []ifrit.Runner{runner1, runner2, runner3})
+			Ω(members).Should(Equal(grouper.Members{
+				{Name: "worker-0", Runner: runner1},
+				{Name: "worker-1", Runner: runner2},
+				{Name: "worker-2", Runner: runner3},
+			}))
+			Ω(members.Validate()).Should(Succeed())
+		})
+
+		It("generates the same names every time", func() {
+			runners := []ifrit.Runner{fake_runner.NewTestRunner(), fake_runner.NewTestRunner()}
+			Ω(grouper.RunnersToMembers("worker", runners)).Should(Equal(grouper.RunnersToMembers("worker", runners)))
+		})
+
+		It("returns an empty list for no runners", func() {
+			Ω(grouper.RunnersToMembers("worker", nil)).Should(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,37 @@
+package grouper
+
+import "time"
+
+/*
+MetricsHooks are callbacks for recording metrics about dynamic group members.
+Each hook is optional, and is invoked from the goroutine waiting on the member,
+so a slow hook delays only the events of its own member.
+
+OnMemberStart is invoked when a member is inserted.  OnMemberReady is invoked
+with the time the member took to become ready.  OnMemberExit is invoked with the
+time since the member was inserted, and the error it exited with.  A member
+which exits before becoming ready does not invoke OnMemberReady.
+*/
+type MetricsHooks struct {
+	OnMemberStart func(name string)
+	OnMemberReady func(name string, startup time.Duration)
+	OnMemberExit  func(name string, lifetime time.Duration, err error)
+}
+
+func (h MetricsHooks) memberStarted(name string) {
+	if h.OnMemberStart != nil {
+		h.OnMemberStart(name)
+	}
+}
+
+func (h MetricsHooks) memberReady(name string, startup time.Duration) {
+	if h.OnMemberReady != nil {
+		h.OnMemberReady(name, startup)
+	}
+}
+
+func (h MetricsHooks) memberExited(name string, lifetime time.Duration, err error) {
+	if h.OnMemberExit != nil {
+		h.OnMemberExit(name, lifetime, err)
+	}
+}
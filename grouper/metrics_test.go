@@ -0,0 +1,128 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsHooks", func() {
+	type readyCall struct {
+		name    string
+		startup time.Duration
+	}
+
+	type exitCall struct {
+		name     string
+		lifetime time.Duration
+		err      error
+	}
+
+	var (
+		starts chan string
+		readys chan readyCall
+		exits  chan exitCall
+
+		poolProcess ifrit.Process
+		client      grouper.DynamicClient
+		runner      *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		starts = make(chan string, 1)
+		readys = make(chan readyCall, 1)
+		exits = make(chan exitCall, 1)
+		runner = fake_runner.NewTestRunner()
+
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity:     1,
+			EventBufferSize: 1,
+			Metrics: grouper.MetricsHooks{
+				OnMemberStart: func(name string) {
+					starts <- name
+				},
+				OnMemberReady: func(name string, startup time.Duration) {
+					readys <- readyCall{name, startup}
+				},
+				OnMemberExit: func(name string, lifetime time.Duration, err error) {
+					exits <- exitCall{name, lifetime, err}
+				},
+			},
+		})
+		client = pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+
+		client.Inserter() <- grouper.Member{"child1", runner}
+	})
+
+	AfterEach(func() {
+		runner.EnsureExit()
+		poolProcess.Signal(os.Kill)
+		Eventually(poolProcess.Wait()).Should(Receive())
+	})
+
+	It("reports a member which becomes ready and then exits", func() {
+		Eventually(starts).Should(Receive(Equal("child1")))
+
+		time.Sleep(5 * time.Millisecond)
+		runner.TriggerReady()
+
+		var ready readyCall
+		Eventually(readys).Should(Receive(&ready))
+		Ω(ready.name).Should(Equal("child1"))
+		Ω(ready.startup).Should(BeNumerically(">=", 5*time.Millisecond))
+
+		runner.TriggerExit(errors.New("boom"))
+
+		var exit exitCall
+		Eventually(exits).Should(Receive(&exit))
+		Ω(exit.name).Should(Equal("child1"))
+		Ω(exit.lifetime).Should(BeNumerically(">=", ready.startup))
+		Ω(exit.err).Should(Equal(errors.New("boom")))
+	})
+
+	It("reports a member which fails before becoming ready", func() {
+		Eventually(starts).Should(Receive(Equal("child1")))
+
+		runner.TriggerExit(errors.New("boom"))
+
+		var exit exitCall
+		Eventually(exits).Should(Receive(&exit))
+		Ω(exit.name).Should(Equal("child1"))
+		Ω(exit.err).Should(Equal(errors.New("boom")))
+		Consistently(readys).ShouldNot(Receive())
+	})
+
+	It("skips hooks which are not set", func() {
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity: 1,
+			Metrics: grouper.MetricsHooks{
+				OnMemberExit: func(name string, lifetime time.Duration, err error) {
+					exits <- exitCall{name, lifetime, err}
+				},
+			},
+		})
+		process := ifrit.Invoke(pool)
+		defer func() {
+			process.Signal(os.Kill)
+			Eventually(process.Wait()).Should(Receive())
+		}()
+
+		other := fake_runner.NewTestRunner()
+		pool.Client().Inserter() <- grouper.Member{"child2", other}
+		other.TriggerReady()
+		other.TriggerExit(nil)
+
+		var exit exitCall
+		Eventually(exits).Should(Receive(&exit))
+		Ω(exit.name).Should(Equal("child2"))
+		Ω(exit.err).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,58 @@
+package grouper
+
+import (
+	"errors"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewNestedMember creates a Member for a group which is nested within another
+group.  When the nested group exits with an ErrorTrace, the name of every member
+in the trace is prefixed with the member's name, as in "outer/inner/worker", so
+that names remain unambiguous across the whole tree.
+
+Only the returned error is prefixed, and so the exit event which the enclosing
+group emits for the member.  Events emitted by the nested group's own client
+carry the names of its members unchanged.
+*/
+func NewNestedMember(name string, group ifrit.Runner) Member {
+	return Member{
+		Name:   name,
+		Runner: nestedGroup{prefix: name, group: group},
+	}
+}
+
+type nestedGroup struct {
+	prefix string
+	group  ifrit.Runner
+}
+
+func (g nestedGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	return prefixError(g.prefix, g.group.Run(signals, ready))
+}
+
+// Tree describes the nested group, or a leaf if it can not describe itself.
+func (g nestedGroup) Tree() TopologyNode {
+	if provider, ok := g.group.(TreeProvider); ok {
+		return provider.Tree()
+	}
+	return TopologyNode{Kind: NodeMember}
+}
+
+func prefixError(prefix string, err error) error {
+	var trace ErrorTrace
+	if !errors.As(err, &trace) {
+		return err
+	}
+
+	prefixed := make(ErrorTrace, 0, len(trace))
+	for _, exit := range trace {
+		exit.Member.Name = prefix + "/" + exit.Member.Name
+		exit.Err = prefixError(prefix, exit.Err)
+		prefixed = append(prefixed, exit)
+	}
+
+	return prefixed
+}
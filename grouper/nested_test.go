@@ -0,0 +1,114 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nested Member", func() {
+	var (
+		worker1 *fake_runner.TestRunner
+		worker2 *fake_runner.TestRunner
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		worker1 = fake_runner.NewTestRunner()
+		worker2 = fake_runner.NewTestRunner()
+
+		inner := grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "worker", Runner: worker1},
+		})
+
+		outer := grouper.NewParallel(os.Interrupt, grouper.Members{
+			grouper.NewNestedMember("inner", inner),
+			{Name: "worker", Runner: worker2},
+		})
+
+		process = ifrit.Background(grouper.NewNestedMember("outer", outer))
+	})
+
+	AfterEach(func() {
+		worker1.EnsureExit()
+		worker2.EnsureExit()
+	})
+
+	It("prefixes member names with the path of the nested groups", func() {
+		worker1.TriggerReady()
+		worker2.TriggerReady()
+		Eventually(process.Ready()).Should(BeClosed())
+
+		signal2 := worker2.WaitForCall()
+		worker1.TriggerExit(errors.New("boom"))
+		Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+		worker2.TriggerExit(nil)
+
+		var err error
+		Eventually(process.Wait()).Should(Receive(&err))
+
+		outerTrace := err.(grouper.ErrorTrace)
+		Ω(outerTrace).Should(HaveLen(2))
+		Ω(outerTrace[0].Member.Name).Should(Equal("outer/inner"))
+		Ω(outerTrace[1].Member.Name).Should(Equal("outer/worker"))
+
+		innerTrace := outerTrace[0].Err.(grouper.ErrorTrace)
+		Ω(innerTrace).Should(HaveLen(1))
+		Ω(innerTrace[0].Member.Name).Should(Equal("outer/inner/worker"))
+		Ω(innerTrace[0].Err).Should(Equal(errors.New("boom")))
+	})
+
+	It("leaves the errors of the members themselves unchanged", func() {
+		worker1.TriggerExit(errors.New("not a trace"))
+		worker2.TriggerExit(nil)
+
+		var err error
+		Eventually(process.Wait()).Should(Receive(&err))
+		outerTrace := err.(grouper.ErrorTrace)
+		Ω(exitIndex("outer/inner", outerTrace)).ShouldNot(Equal(-1))
+
+		innerTrace := outerTrace[exitIndex("outer/inner", outerTrace)].Err.(grouper.ErrorTrace)
+		Ω(exitIndex("outer/inner/worker", innerTrace)).ShouldNot(Equal(-1))
+		Ω(errors.Unwrap(innerTrace[exitIndex("outer/inner/worker", innerTrace)].Err)).Should(Equal(errors.New("not a trace")))
+	})
+})
+
+var _ = Describe("Nested Member in a dynamic group", func() {
+	It("emits the nested group's exit with prefixed member names", func() {
+		worker := fake_runner.NewTestRunner()
+		inner := grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "worker", Runner: worker},
+		})
+
+		pool := grouper.NewDynamic(nil, 1, 1)
+		client := pool.Client()
+		entrances := client.EntranceListener()
+		exits := client.ExitListener()
+		process := ifrit.Background(pool)
+		defer func() {
+			worker.EnsureExit()
+			process.Signal(os.Kill)
+			Eventually(process.Wait()).Should(Receive())
+		}()
+
+		Eventually(client.Inserter()).Should(BeSent(grouper.NewNestedMember("inner", inner)))
+		worker.TriggerReady()
+		Eventually(entrances).Should(Receive())
+		worker.TriggerExit(errors.New("boom"))
+
+		var exit grouper.ExitEvent
+		Eventually(exits).Should(Receive(&exit))
+		Ω(exit.Member.Name).Should(Equal("inner"))
+
+		innerTrace := exit.Err.(grouper.ErrorTrace)
+		Ω(innerTrace).Should(HaveLen(1))
+		Ω(innerTrace[0].Member.Name).Should(Equal("inner/worker"))
+		Ω(innerTrace[0].Err).Should(Equal(errors.New("boom")))
+	})
+})
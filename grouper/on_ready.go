@@ -0,0 +1,46 @@
+package grouper
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+WithOnReady wraps a group, calling onReady exactly once when the group becomes
+ready; for a static group, that is once every member is ready.  The wrapper is
+itself ready at the same moment.  onReady is run in its own goroutine, so it
+does not delay the group from receiving signals.
+*/
+func WithOnReady(group ifrit.Runner, onReady func()) ifrit.Runner {
+	return onReadyGroup{
+		group:   group,
+		onReady: onReady,
+	}
+}
+
+type onReadyGroup struct {
+	group   ifrit.Runner
+	onReady func()
+}
+
+func (g onReadyGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	process := ifrit.Background(g.group)
+	processReady := process.Ready()
+	exit := process.Wait()
+
+	for {
+		select {
+		case signal := <-signals:
+			process.Signal(signal)
+
+		case <-processReady:
+			processReady = nil
+			close(ready)
+			go g.onReady()
+
+		case err := <-exit:
+			return err
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package grouper_test
+
+import (
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithOnReady", func() {
+	var (
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		groupProcess ifrit.Process
+		calls        int32
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		atomic.StoreInt32(&calls, 0)
+
+		group := grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+		})
+
+		groupProcess = ifrit.Background(grouper.WithOnReady(group, func() {
+			atomic.AddInt32(&calls, 1)
+		}))
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		ginkgomon.Kill(groupProcess)
+	})
+
+	readyCalls := func() int32 {
+		return atomic.LoadInt32(&calls)
+	}
+
+	It("fires once, after the last member is ready", func() {
+		childRunner1.TriggerReady()
+		Consistently(readyCalls, Δ).Should(BeZero())
+
+		childRunner2.TriggerReady()
+		Eventually(readyCalls).Should(Equal(int32(1)))
+		Ω(groupProcess.Ready()).Should(BeClosed())
+		Consistently(readyCalls, Δ).Should(Equal(int32(1)))
+	})
+
+	It("does not fire when the group never becomes ready", func() {
+		childRunner1.TriggerReady()
+		childRunner2.TriggerExit(nil)
+		childRunner1.TriggerExit(nil)
+
+		Eventually(groupProcess.Wait()).Should(Receive())
+		Ω(readyCalls()).Should(BeZero())
+	})
+})
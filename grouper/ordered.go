@@ -3,37 +3,114 @@ package grouper
 import (
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
 
 /*
-NewOrdered starts it's members in order, each member starting when the previous
+NewOrdered starts its members in order, each member starting when the previous
 becomes ready.  On shutdown, it will shut the started processes down in reverse order.
 Use an ordered group to describe a list of dependent processes, where each process
 depends upon the previous being available in order to function correctly.
+
+When a member exits, the group shuts down, signaling the remaining members with
+the termination signal.  A nil termination signal is not propagated: the other
+members are left running, and the group shuts down only when it is signaled, or
+exits once every member has exited.  This is the same as an ExitMode of
+StopNever, which may be given to NewOrderedWithConfig to make it explicit.
 */
-func NewOrdered(terminationSignal os.Signal, members Members) ifrit.Runner {
+func NewOrdered(terminationSignal os.Signal, members Members) OrderedGroup {
+	return NewOrderedWithConfig(OrderedConfig{
+		TerminationSignal: terminationSignal,
+		Members:           members,
+	})
+}
+
+/*
+OrderedConfig describes an ordered group.  TerminationSignal and Members have
+the same meaning as the arguments to NewOrdered.
+
+StopTimeout, if set, bounds how long the group waits for each member to exit
+during shutdown before moving on to signal the next.  Members which did not
+exit in time are left running, and the group returns
+ErrShutdownDeadlineExceeded naming them.
+
+ExitMode decides which member exits shut the group down, as for a dynamic
+group.  The exits of members left running are reported in the group's
+ErrorTrace once it stops.  A member restarted by StaticClient.RestartFrom
+forgets its earlier exit.
+*/
+type OrderedConfig struct {
+	TerminationSignal os.Signal
+	Members           Members
+	StopTimeout       time.Duration
+	ExitMode          ExitMode
+}
+
+/*
+NewOrderedWithConfig creates an ordered group from an OrderedConfig, allowing
+the optional settings to be configured.
+*/
+func NewOrderedWithConfig(config OrderedConfig) OrderedGroup {
 	return &orderedGroup{
-		terminationSignal: terminationSignal,
+		client:            newStaticClient(),
+		terminationSignal: config.TerminationSignal,
 		pool:              make(map[string]ifrit.Process),
-		members:           members,
+		members:           config.Members,
+		stopTimeout:       config.StopTimeout,
+		exitMode:          config.ExitMode,
+		topology:          newTopology(),
 	}
 }
 
+/*
+An OrderedGroup is an ordered group, with a client which can restart part of
+the group while it runs.
+*/
+type OrderedGroup interface {
+	ifrit.Runner
+	Client() StaticClient
+
+	// GroupConfig reports the group's termination signal, and that it is
+	// ordered.
+	GroupConfig() GroupConfigInfo
+}
+
 type orderedGroup struct {
+	client            staticClient
 	terminationSignal os.Signal
 	pool              map[string]ifrit.Process
 	members           Members
+	stopTimeout       time.Duration
+	exitMode          ExitMode
+	topology          *topology
+}
+
+func (g *orderedGroup) Client() StaticClient {
+	return g.client
+}
+
+// GroupConfig reports the group's termination signal, and that it is ordered.
+func (g *orderedGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal, Ordered: true}
+}
+
+// Tree describes the group and the members it has started.
+func (g *orderedGroup) Tree() TopologyNode {
+	return g.topology.tree(NodeOrdered)
 }
 
 func (g *orderedGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer close(g.client.completeNotifier)
+	defer g.topology.setState(ifrit.StateExited)
+
 	err := g.validate()
 	if err != nil {
 		return err
 	}
 
-	signal, errTrace := g.orderedStart(signals)
+	signal, errTrace := g.orderedStart(signals, 0)
 	if errTrace != nil {
 		return g.stop(g.terminationSignal, errTrace)
 	}
@@ -43,6 +120,8 @@ func (g *orderedGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 	}
 
 	close(ready)
+	close(g.client.readyNotifier)
+	g.topology.setState(ifrit.StateReady)
 
 	signal, errTrace = g.waitForSignal(signals, errTrace)
 	return g.stop(signal, errTrace)
@@ -52,16 +131,18 @@ func (g *orderedGroup) validate() error {
 	return g.members.Validate()
 }
 
-func (g *orderedGroup) orderedStart(signals <-chan os.Signal) (os.Signal, ErrorTrace) {
-	for _, member := range g.members {
+// orderedStart starts the members in order, from the given index.
+func (g *orderedGroup) orderedStart(signals <-chan os.Signal, from int) (os.Signal, ErrorTrace) {
+	for _, member := range g.members[from:] {
 		p := ifrit.Background(member)
+		// The starting member joins the pool at once, so that a signal received
+		// while it starts is also sent to it, and it is waited for.
+		g.pool[member.Name] = p
+		g.topology.started(member, p)
 		select {
 		case <-p.Ready():
-			g.pool[member.Name] = p
 		case err := <-p.Wait():
-			return nil, ErrorTrace{
-				ExitEvent{Member: member, Err: err},
-			}
+			return nil, ErrorTrace{newExitEvent(member, err)}
 		case signal := <-signals:
 			return signal, nil
 		}
@@ -71,34 +152,108 @@ func (g *orderedGroup) orderedStart(signals <-chan os.Signal) (os.Signal, ErrorT
 }
 
 func (g *orderedGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
-	cases := make([]reflect.SelectCase, 0, len(g.pool)+1)
-	for i := 0; i < len(g.pool); i++ {
+	for {
+		exited := errTrace.memberNames()
+		if len(exited) == len(g.members) {
+			return nil, errTrace
+		}
+
+		cases := make([]reflect.SelectCase, 0, len(g.members)+2)
+		for _, member := range g.members {
+			waitChan := reflect.Zero(waitChanType)
+			if _, found := exited[member.Name]; !found {
+				waitChan = reflect.ValueOf(g.pool[member.Name].Wait())
+			}
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: waitChan,
+			})
+		}
 		cases = append(cases, reflect.SelectCase{
 			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(g.pool[g.members[i].Name].Wait()),
+			Chan: reflect.ValueOf(signals),
+		}, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(g.client.restartChannel),
 		})
+
+		chosen, recv, _ := reflect.Select(cases)
+		switch chosen {
+		case len(cases) - 2:
+			return recv.Interface().(os.Signal), errTrace
+
+		case len(cases) - 1:
+			req := recv.Interface().(restartRequest)
+			signal, restartTrace := g.restartFrom(req, signals)
+			if from := g.indexOf(req.Name); from >= 0 {
+				errTrace = errTrace.without(g.members[from:])
+			}
+			if signal != nil {
+				return signal, append(errTrace, restartTrace...)
+			}
+			continue
+		}
+
+		var err error
+		if !recv.IsNil() {
+			err = recv.Interface().(error)
+		}
+
+		errTrace = append(errTrace, newExitEvent(g.members[chosen], err))
+
+		if g.terminationSignal != nil && g.exitMode.stops(err) {
+			return g.terminationSignal, errTrace
+		}
 	}
-	cases = append(cases, reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(signals),
-	})
+}
 
-	chosen, recv, _ := reflect.Select(cases)
-	if chosen == len(cases)-1 {
-		return recv.Interface().(os.Signal), errTrace
+func (g *orderedGroup) indexOf(name string) int {
+	for i, member := range g.members {
+		if member.Name == name {
+			return i
+		}
 	}
+	return -1
+}
 
-	var err error
-	if !recv.IsNil() {
-		err = recv.Interface().(error)
+// restartFrom handles a RestartFrom request.  It returns a signal if the group
+// should shut down, along with the exit of a member which failed to restart.
+// A failed restart always shuts the group down, even without a termination
+// signal, as the members after the failed one are no longer running.
+func (g *orderedGroup) restartFrom(req restartRequest, signals <-chan os.Signal) (os.Signal, ErrorTrace) {
+	from := g.indexOf(req.Name)
+	if from < 0 {
+		req.Response <- ErrMemberNotFound{req.Name}
+		return nil, nil
 	}
 
-	errTrace = append(errTrace, ExitEvent{
-		Member: g.members[chosen],
-		Err:    err,
-	})
+	signal := memberSignal(nil, g.terminationSignal)
+	laggards := []string{}
+	for i := len(g.members) - 1; i >= from; i-- {
+		p := g.pool[g.members[i].Name]
+		p.Signal(signal)
+		if _, ok := g.waitForExit(p); !ok {
+			laggards = append(laggards, g.members[i].Name)
+		}
+	}
+	if len(laggards) > 0 {
+		req.Response <- ErrShutdownDeadlineExceeded{Members: laggards}
+		return signal, nil
+	}
 
-	return g.terminationSignal, errTrace
+	signal, errTrace := g.orderedStart(signals, from)
+	if errTrace != nil {
+		exit := errTrace[0]
+		req.Response <- StartupError{Member: exit.Member, Err: exit.Err}
+		return memberSignal(nil, g.terminationSignal), errTrace
+	}
+	if signal != nil {
+		req.Response <- ErrRestartInterrupted{Signal: signal}
+		return signal, nil
+	}
+
+	req.Response <- nil
+	return nil, nil
 }
 
 func (g *orderedGroup) stop(signal os.Signal, errTrace ErrorTrace) error {
@@ -113,6 +268,7 @@ func (g *orderedGroup) stop(signal os.Signal, errTrace ErrorTrace) error {
 		}
 	}
 
+	laggards := []string{}
 	for i := len(g.pool) - 1; i >= 0; i-- {
 		m := g.members[i]
 		if _, found := exited[m.Name]; found {
@@ -121,20 +277,44 @@ func (g *orderedGroup) stop(signal os.Signal, errTrace ErrorTrace) error {
 		if p, ok := g.pool[m.Name]; ok {
 			p.Signal(signal)
 
-			err := <-p.Wait()
-			errTrace = append(errTrace, ExitEvent{
-				Member: m,
-				Err:    err,
-			})
+			err, ok := g.waitForExit(p)
+			if !ok {
+				laggards = append(laggards, m.Name)
+				continue
+			}
+
+			errTrace = append(errTrace, newExitEvent(m, err))
 			if err != nil {
 				errOccurred = true
 			}
 		}
 	}
 
+	if len(laggards) > 0 {
+		return ErrShutdownDeadlineExceeded{Members: laggards}
+	}
+
 	if errOccurred {
 		return errTrace
 	}
 
 	return nil
 }
+
+func (g *orderedGroup) waitForExit(p ifrit.Process) (error, bool) {
+	if g.stopTimeout <= 0 {
+		return <-p.Wait(), true
+	}
+
+	timer := time.NewTimer(g.stopTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-p.Wait():
+		return err, true
+	case <-timer.C:
+		// The wait channel is buffered, so its eventual result is simply
+		// discarded.
+		return nil, false
+	}
+}
@@ -163,8 +163,8 @@ var _ = Describe("Ordered Group", func() {
 						errTrace := err.(grouper.ErrorTrace)
 						Ω(errTrace).Should(HaveLen(3))
 
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
+						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{Member: grouper.Member{"child1", childRunner1}, Err: nil}))
+						Ω(errTrace).Should(ContainElement(grouper.ExitEvent{Member: grouper.Member{"child2", childRunner2}, Err: errors.New("Fail")}))
 					})
 				})
 			})
@@ -185,13 +185,103 @@ var _ = Describe("Ordered Group", func() {
 
 				Eventually(groupProcess.Wait()).Should(Receive(&err))
 				errTrace := err.(grouper.ErrorTrace)
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil}))
-				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")}))
+				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{Member: grouper.Member{"child1", childRunner1}, Err: nil}))
+				Ω(errTrace).Should(ContainElement(grouper.ExitEvent{Member: grouper.Member{"child2", childRunner2}, Err: errors.New("Fail")}))
 				Ω(exitIndex("child1", errTrace)).Should(BeNumerically(">", exitIndex("child2", errTrace)))
 			})
 		})
 	})
 
+	Describe("when signaled during startup", func() {
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+			childRunner3 = fake_runner.NewTestRunner()
+
+			groupRunner = grouper.NewOrdered(os.Interrupt, grouper.Members{
+				{"child1", childRunner1},
+				{"child2", childRunner2},
+				{"child3", childRunner3},
+			})
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			childRunner3.EnsureExit()
+		})
+
+		It("signals and waits for the member which is starting, and returns", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+
+			groupProcess.Signal(syscall.SIGUSR2)
+
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signal1, Δ).ShouldNot(Receive())
+			Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+
+			childRunner2.TriggerExit(nil)
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			childRunner1.TriggerExit(nil)
+
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			Ω(childRunner3.RunCallCount()).Should(BeZero())
+		})
+	})
+
+	Describe("Stop timeout", func() {
+		var hanging *hangingRunner
+
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			hanging = newHangingRunner()
+			childRunner3 = fake_runner.NewTestRunner()
+
+			groupRunner = grouper.NewOrderedWithConfig(grouper.OrderedConfig{
+				TerminationSignal: os.Interrupt,
+				Members: grouper.Members{
+					{Name: "child1", Runner: childRunner1},
+					{Name: "hanging", Runner: hanging},
+					{Name: "child3", Runner: childRunner3},
+				},
+				StopTimeout: 100 * time.Millisecond,
+			})
+			groupProcess = ifrit.Background(groupRunner)
+
+			childRunner1.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner3.EnsureExit()
+			hanging.Release()
+		})
+
+		It("moves on from a member which ignores its signal, and names it", func() {
+			signal1 := childRunner1.WaitForCall()
+			signal3 := childRunner3.WaitForCall()
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+			childRunner3.TriggerExit(nil)
+
+			Eventually(hanging.ReceivedSignals).Should(ConsistOf(os.Interrupt))
+			Consistently(signal1, 50*time.Millisecond).ShouldNot(Receive())
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(Equal(grouper.ErrShutdownDeadlineExceeded{Members: []string{"hanging"}}))
+		})
+	})
+
 	Describe("Stop", func() {
 
 		var runnerIndex int64
@@ -297,6 +387,262 @@ var _ = Describe("Ordered Group", func() {
 			})
 		})
 	})
+
+	Describe("RestartFrom", func() {
+		var (
+			group  grouper.OrderedGroup
+			events chan string
+		)
+
+		member := func(name string) grouper.Member {
+			return grouper.Member{
+				Name: name,
+				Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					events <- "start " + name
+					close(ready)
+					<-signals
+					events <- "stop " + name
+					return nil
+				}),
+			}
+		}
+
+		BeforeEach(func() {
+			events = make(chan string, 100)
+			group = grouper.NewOrdered(os.Interrupt, grouper.Members{
+				member("child1"),
+				member("child2"),
+				member("child3"),
+			})
+			groupProcess = ifrit.Invoke(group)
+
+			for _, name := range []string{"child1", "child2", "child3"} {
+				Ω(events).Should(Receive(Equal("start " + name)))
+			}
+		})
+
+		AfterEach(func() {
+			groupProcess.Signal(os.Kill)
+			Eventually(groupProcess.Wait()).Should(Receive())
+		})
+
+		It("bounces the named member and those after it, leaving earlier members running", func() {
+			Ω(group.Client().RestartFrom("child2")).Should(Succeed())
+
+			Ω(events).Should(Receive(Equal("stop child3")))
+			Ω(events).Should(Receive(Equal("stop child2")))
+			Ω(events).Should(Receive(Equal("start child2")))
+			Ω(events).Should(Receive(Equal("start child3")))
+			Consistently(events, Δ).ShouldNot(Receive())
+		})
+
+		It("stops every member, including the restarted ones, on shutdown", func() {
+			Ω(group.Client().RestartFrom("child2")).Should(Succeed())
+			for i := 0; i < 4; i++ {
+				Ω(events).Should(Receive())
+			}
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+
+			Ω(events).Should(Receive(Equal("stop child3")))
+			Ω(events).Should(Receive(Equal("stop child2")))
+			Ω(events).Should(Receive(Equal("stop child1")))
+		})
+
+		It("returns ErrMemberNotFound for an unknown member", func() {
+			Ω(group.Client().RestartFrom("child4")).Should(Equal(grouper.ErrMemberNotFound{Name: "child4"}))
+			Consistently(events, Δ).ShouldNot(Receive())
+		})
+
+		Context("when the group has exited", func() {
+			It("returns ErrMemberNotFound", func() {
+				groupProcess.Signal(os.Interrupt)
+				Eventually(groupProcess.Wait()).Should(Receive())
+
+				Ω(group.Client().RestartFrom("child2")).Should(Equal(grouper.ErrMemberNotFound{Name: "child2"}))
+			})
+		})
+	})
+
+	Describe("ReadyAll", func() {
+		var group grouper.OrderedGroup
+
+		BeforeEach(func() {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+
+			group = grouper.NewOrdered(os.Interrupt, grouper.Members{
+				{"child1", childRunner1},
+				{"child2", childRunner2},
+			})
+			groupProcess = ifrit.Background(group)
+		})
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			groupProcess.Signal(os.Kill)
+			Eventually(groupProcess.Wait()).Should(Receive())
+		})
+
+		It("closes once every member is ready", func() {
+			readyAll := group.Client().ReadyAll()
+
+			childRunner1.TriggerReady()
+			Consistently(readyAll, Δ).ShouldNot(BeClosed())
+
+			childRunner2.TriggerReady()
+			Eventually(readyAll).Should(BeClosed())
+			Ω(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("is already closed when requested after the members are ready", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			Ω(group.Client().ReadyAll()).Should(BeClosed())
+		})
+	})
+
+	Describe("RestartFrom without a termination signal", func() {
+		It("reports a restarted member which fails to start", func() {
+			var starts int32
+			group := grouper.NewOrdered(nil, grouper.Members{
+				{Name: "child1", Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					<-signals
+					return nil
+				})},
+				{Name: "child2", Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					if atomic.AddInt32(&starts, 1) > 1 {
+						return errors.New("boom")
+					}
+					close(ready)
+					<-signals
+					return nil
+				})},
+			})
+			groupProcess := ifrit.Invoke(group)
+
+			err := group.Client().RestartFrom("child2")
+			Ω(err).Should(BeAssignableToTypeOf(grouper.StartupError{}))
+
+			groupProcess.Signal(os.Interrupt)
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+
+			errTrace, ok := err.(grouper.ErrorTrace)
+			Ω(ok).Should(BeTrue())
+			Ω(exitIndex("child2", errTrace)).ShouldNot(Equal(-1))
+			Ω(errTrace[exitIndex("child2", errTrace)].Err).Should(MatchError("boom"))
+		})
+	})
+
+	Describe("when a middle member exits cleanly", func() {
+		var signal1, signal3 <-chan os.Signal
+
+		start := func(config grouper.OrderedConfig) {
+			childRunner1 = fake_runner.NewTestRunner()
+			childRunner2 = fake_runner.NewTestRunner()
+			childRunner3 = fake_runner.NewTestRunner()
+
+			config.Members = grouper.Members{
+				{"child1", childRunner1},
+				{"child2", childRunner2},
+				{"child3", childRunner3},
+			}
+			groupProcess = ifrit.Background(grouper.NewOrderedWithConfig(config))
+
+			signal1 = childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			signal3 = childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			childRunner2.TriggerExit(nil)
+		}
+
+		AfterEach(func() {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			childRunner3.EnsureExit()
+			Eventually(groupProcess.Wait()).Should(Receive())
+		})
+
+		Context("with a termination signal", func() {
+			It("shuts the group down with the termination signal", func() {
+				start(grouper.OrderedConfig{TerminationSignal: os.Interrupt})
+
+				Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+				childRunner3.TriggerExit(nil)
+				Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+				childRunner1.TriggerExit(nil)
+
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
+		Context("with a nil termination signal", func() {
+			BeforeEach(func() {
+				start(grouper.OrderedConfig{})
+			})
+
+			It("leaves the other members running", func() {
+				Consistently(signal3, Δ).ShouldNot(Receive())
+				Consistently(signal1, Δ).ShouldNot(Receive())
+				Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+			})
+
+			It("reports the exit once it is signaled", func() {
+				groupProcess.Signal(syscall.SIGUSR2)
+				Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+				childRunner3.TriggerExit(errors.New("Fail"))
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+				childRunner1.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				errTrace := err.(grouper.ErrorTrace)
+				Ω(errTrace).Should(HaveLen(3))
+				Ω(exitIndex("child2", errTrace)).ShouldNot(Equal(-1))
+				Ω(errTrace[exitIndex("child2", errTrace)].Err).ShouldNot(HaveOccurred())
+			})
+
+			It("exits once every member has exited", func() {
+				childRunner3.TriggerExit(nil)
+				childRunner1.TriggerExit(nil)
+
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
+		Context("with an ExitMode of StopOnErrorExit", func() {
+			BeforeEach(func() {
+				start(grouper.OrderedConfig{
+					TerminationSignal: os.Interrupt,
+					ExitMode:          grouper.StopOnErrorExit,
+				})
+			})
+
+			It("leaves the other members running", func() {
+				Consistently(signal3, Δ).ShouldNot(Receive())
+				Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+			})
+
+			It("shuts down when another member fails", func() {
+				childRunner1.TriggerExit(errors.New("Fail"))
+
+				Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+				childRunner3.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+				Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+			})
+		})
+	})
 })
 
 func exitIndex(name string, errTrace grouper.ErrorTrace) int {
@@ -0,0 +1,22 @@
+package grouper
+
+/*
+An OverflowPolicy decides what a dynamic group does when it has an event for a
+listener whose channel is full, because the listener is not keeping up.
+*/
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the listener to receive the event.  The group,
+	// and every other listener, waits with it.  This is the default.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest discards the oldest event waiting in the listener's
+	// channel to make room for the new one.  A listener with an unbuffered
+	// channel misses any event it is not ready to receive.
+	OverflowDropOldest
+
+	// OverflowCloseListener detaches the listener, and closes its channel, so
+	// that it learns it has missed events.
+	OverflowCloseListener
+)
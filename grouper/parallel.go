@@ -1,21 +1,104 @@
 package grouper
 
 import (
+	"fmt"
+	"math/rand"
 	"os"
 	"reflect"
+	"sort"
+	"time"
 
 	"github.com/tedsuo/ifrit"
 )
 
 /*
-NewParallel starts it's members simultaneously.  Use a parallel group to describe a set
-of concurrent but independent processes.
+NewParallel starts its members simultaneously.  Use a parallel group to describe a set
+of concurrent but independent processes.  Members are signaled concurrently when
+stopping; use NewParallelWithConfig to stop them in priority tiers.
 */
 func NewParallel(terminationSignal os.Signal, members Members) ifrit.Runner {
+	return NewParallelBounded(terminationSignal, members, 0)
+}
+
+/*
+Parallel is a convenience for running unnamed runners as a parallel group.  The
+members are named by RunnersToMembers with the prefix "runner".
+*/
+func Parallel(terminationSignal os.Signal, runners ...ifrit.Runner) ifrit.Runner {
+	return NewParallel(terminationSignal, RunnersToMembers("runner", runners))
+}
+
+/*
+NewParallelBounded is like NewParallel, but starts at most maxConcurrent members
+at a time.  As each starting member becomes ready, the next member is started,
+until every member is running.  A maxConcurrent of zero or less starts every
+member at once.
+*/
+func NewParallelBounded(terminationSignal os.Signal, members Members, maxConcurrent int) ifrit.Runner {
+	return NewParallelWithConfig(ParallelConfig{
+		TerminationSignal: terminationSignal,
+		Members:           members,
+		MaxConcurrent:     maxConcurrent,
+	})
+}
+
+/*
+ParallelConfig describes a parallel group.  TerminationSignal, Members and
+MaxConcurrent have the same meaning as the arguments to NewParallelBounded.
+
+StartupTimeout, if set, bounds how long each member may take to become ready,
+from the moment it is started.  If a member is not ready in time, the group
+stops every started member with the termination signal, and returns
+ErrStartupTimeout.
+
+StartupGrace, if set, changes how the group handles a signal received before
+every member is ready.  By default, the started members are signaled at once,
+whether or not they are ready.  With a grace period, no further members are
+started, and the group waits up to StartupGrace for the members which are
+starting to become ready or exit, so that each is signaled only once it can shut
+down cleanly.  Members still starting when the grace period ends are signaled
+regardless.  Either way, each started member is signaled exactly once.
+
+ShutdownPriorities, if set, orders shutdown by member name: members with a
+higher priority are stopped, and have exited, before members with a lower
+priority are signaled.  Members with the same priority, including those not
+named, which have priority zero, are stopped concurrently.
+
+Deterministic is a test and debugging aid, for writing stable assertions about
+shutdown.  When set, the members of each shutdown tier are signaled one at a
+time, in an order shuffled by DeterministicSeed, and each is awaited before the
+next is signaled, so that the same seed produces the same order of exits in the
+ErrorTrace.  It does not affect startup.  The order is arbitrary, and may change
+between releases; it is not a production ordering guarantee, for which use an
+ordered group or ShutdownPriorities.
+*/
+type ParallelConfig struct {
+	TerminationSignal  os.Signal
+	Members            Members
+	MaxConcurrent      int
+	ShutdownPriorities map[string]int
+	StartupTimeout     time.Duration
+	StartupGrace       time.Duration
+	Deterministic      bool
+	DeterministicSeed  int64
+}
+
+/*
+NewParallelWithConfig creates a parallel group from a ParallelConfig, allowing
+the optional settings to be configured.
+*/
+func NewParallelWithConfig(config ParallelConfig) ifrit.Runner {
 	return parallelGroup{
-		terminationSignal: terminationSignal,
+		terminationSignal: config.TerminationSignal,
 		pool:              make(map[string]ifrit.Process),
-		members:           members,
+		members:           config.Members,
+		maxConcurrent:     config.MaxConcurrent,
+		priorities:        config.ShutdownPriorities,
+		startupTimeout:    config.StartupTimeout,
+		startupGrace:      config.StartupGrace,
+		deterministic:     config.Deterministic,
+		deterministicSeed: config.DeterministicSeed,
+		topology:          newTopology(),
 	}
 }
 
@@ -23,87 +106,212 @@ type parallelGroup struct {
 	terminationSignal os.Signal
 	pool              map[string]ifrit.Process
 	members           Members
+	maxConcurrent     int
+	priorities        map[string]int
+	startupTimeout    time.Duration
+	startupGrace      time.Duration
+	deterministic     bool
+	deterministicSeed int64
+	topology          *topology
 }
 
+var (
+	waitChanType  = reflect.TypeOf((<-chan error)(nil))
+	readyChanType = reflect.TypeOf((<-chan struct{})(nil))
+	timeChanType  = reflect.TypeOf((<-chan time.Time)(nil))
+)
+
 func (g parallelGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.topology.setState(ifrit.StateExited)
+
 	err := g.validate()
 	if err != nil {
 		return err
 	}
 
-	signal, errTrace := g.parallelStart(signals)
-	if errTrace != nil {
-		return g.stop(g.terminationSignal, errTrace)
+	signal, errTrace, err := g.parallelStart(signals)
+	if err != nil {
+		g.stop(g.terminationSignal, nil)
+		return err
 	}
 
 	if signal != nil {
 		return g.stop(signal, errTrace)
 	}
 
+	if errTrace.requiredExited() {
+		return g.stop(g.terminationSignal, errTrace)
+	}
+
 	close(ready)
+	g.topology.setState(ifrit.StateReady)
 
 	signal, errTrace = g.waitForSignal(signals, errTrace)
 	return g.stop(signal, errTrace)
 }
 
+// GroupConfig reports the group's termination signal.
+func (g parallelGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal}
+}
+
+// Tree describes the group and the members it has started.
+func (g parallelGroup) Tree() TopologyNode {
+	return g.topology.tree(NodeParallel)
+}
+
 func (o parallelGroup) validate() error {
 	return o.members.Validate()
 }
 
-func (g *parallelGroup) parallelStart(signals <-chan os.Signal) (os.Signal, ErrorTrace) {
+func (g *parallelGroup) parallelStart(signals <-chan os.Signal) (os.Signal, ErrorTrace, error) {
 	numMembers := len(g.members)
+	if numMembers == 0 {
+		return nil, nil, nil
+	}
 
-	processes := make([]ifrit.Process, numMembers)
-	cases := make([]reflect.SelectCase, 2*numMembers+1)
+	maxConcurrent := g.maxConcurrent
+	if maxConcurrent <= 0 || maxConcurrent > numMembers {
+		maxConcurrent = numMembers
+	}
 
-	for i, member := range g.members {
-		process := ifrit.Background(member)
+	// Each member has three cases: its exit, its readiness, and its
+	// startup timeout.  The received signal is the last case.
+	cases := make([]reflect.SelectCase, 3*numMembers+1)
+	timers := make([]*time.Timer, numMembers)
+	defer func() {
+		for _, timer := range timers {
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}()
 
-		processes[i] = process
+	for i := range g.members {
+		cases[3*i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.Zero(waitChanType),
+		}
 
-		cases[2*i] = reflect.SelectCase{
+		cases[3*i+1] = reflect.SelectCase{
 			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(process.Wait()),
+			Chan: reflect.Zero(readyChanType),
 		}
 
-		cases[2*i+1] = reflect.SelectCase{
+		cases[3*i+2] = reflect.SelectCase{
 			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(process.Ready()),
+			Chan: reflect.Zero(timeChanType),
 		}
 	}
 
-	cases[2*numMembers] = reflect.SelectCase{
+	start := func(i int) {
+		process := ifrit.Background(g.members[i])
+		g.pool[g.members[i].Name] = process
+		g.topology.started(g.members[i], process)
+		cases[3*i].Chan = reflect.ValueOf(process.Wait())
+		cases[3*i+1].Chan = reflect.ValueOf(process.Ready())
+		if g.startupTimeout > 0 {
+			timers[i] = time.NewTimer(g.startupTimeout)
+			cases[3*i+2].Chan = reflect.ValueOf((<-chan time.Time)(timers[i].C))
+		}
+	}
+
+	numStarted := 0
+	for ; numStarted < maxConcurrent; numStarted++ {
+		start(numStarted)
+	}
+
+	cases[3*numMembers] = reflect.SelectCase{
 		Dir:  reflect.SelectRecv,
 		Chan: reflect.ValueOf(signals),
 	}
 
+	// Once a signal is received during the startup grace, the last case
+	// becomes the grace timer, and the received signal is held in shutdown.
+	var shutdown os.Signal
+	var grace *time.Timer
+	defer func() {
+		if grace != nil {
+			grace.Stop()
+		}
+	}()
+
+	// An optional member which exits during startup is recorded, and counted
+	// as done, rather than failing the group.
+	var errTrace ErrorTrace
 	numReady := 0
 	for {
 		chosen, recv, _ := reflect.Select(cases)
 
 		switch {
-		case chosen == 2*numMembers:
-			return recv.Interface().(os.Signal), nil
-		case chosen%2 == 0:
-			recvError, _ := recv.Interface().(error)
-			return nil, ErrorTrace{ExitEvent{Member: g.members[chosen/2], Err: recvError}}
+		case chosen == 3*numMembers && shutdown != nil:
+			return shutdown, errTrace, nil
+		case chosen == 3*numMembers:
+			signal := recv.Interface().(os.Signal)
+			if g.startupGrace <= 0 || numReady == numStarted {
+				return signal, errTrace, nil
+			}
+			shutdown = signal
+			grace = time.NewTimer(g.startupGrace)
+			cases[3*numMembers].Chan = reflect.ValueOf((<-chan time.Time)(grace.C))
+		case chosen%3 == 2 && shutdown != nil:
+			return shutdown, errTrace, nil
+		case chosen%3 == 2:
+			return nil, errTrace, ErrStartupTimeout{Member: g.members[chosen/3].Name, Timeout: g.startupTimeout}
 		default:
-			cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
-			g.pool[g.members[chosen/2].Name] = processes[chosen/2]
+			i := chosen / 3
+			if chosen%3 == 0 {
+				recvError, _ := recv.Interface().(error)
+				errTrace = append(errTrace, newStartupExitEvent(g.members[i], recvError))
+				if !g.members[i].Optional() && shutdown == nil {
+					return nil, errTrace, nil
+				}
+				cases[3*i].Chan = reflect.Zero(waitChanType)
+			}
+
+			cases[3*i+1].Chan = reflect.Zero(readyChanType)
+			cases[3*i+2].Chan = reflect.Zero(timeChanType)
+			if timers[i] != nil {
+				timers[i].Stop()
+			}
+
 			numReady++
+			if shutdown != nil {
+				if numReady == numStarted {
+					return shutdown, errTrace, nil
+				}
+				break
+			}
 			if numReady == numMembers {
-				return nil, nil
+				return nil, errTrace, nil
+			}
+			if numStarted < numMembers {
+				start(numStarted)
+				numStarted++
 			}
 		}
 	}
 }
 
 func (g *parallelGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTrace) (os.Signal, ErrorTrace) {
+	// A group without members runs until it is signaled.
+	if len(g.pool) == 0 {
+		return <-signals, errTrace
+	}
+
+	exited := errTrace.memberNames()
+
+	running := 0
 	cases := make([]reflect.SelectCase, 0, len(g.pool)+1)
 	for i := 0; i < len(g.pool); i++ {
+		waitChan := reflect.Zero(waitChanType)
+		if _, found := exited[g.members[i].Name]; !found {
+			waitChan = reflect.ValueOf(g.pool[g.members[i].Name].Wait())
+			running++
+		}
 		cases = append(cases, reflect.SelectCase{
 			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(g.pool[g.members[i].Name].Wait()),
+			Chan: waitChan,
 		})
 	}
 	cases = append(cases, reflect.SelectCase{
@@ -111,66 +319,97 @@ func (g *parallelGroup) waitForSignal(signals <-chan os.Signal, errTrace ErrorTr
 		Chan: reflect.ValueOf(signals),
 	})
 
-	chosen, recv, _ := reflect.Select(cases)
-	if chosen == len(cases)-1 {
-		return recv.Interface().(os.Signal), errTrace
-	}
+	for running > 0 {
+		chosen, recv, _ := reflect.Select(cases)
+		if chosen == len(cases)-1 {
+			return recv.Interface().(os.Signal), errTrace
+		}
 
-	var err error
-	if !recv.IsNil() {
-		err = recv.Interface().(error)
-	}
+		var err error
+		if !recv.IsNil() {
+			err = recv.Interface().(error)
+		}
 
-	errTrace = append(errTrace, ExitEvent{
-		Member: g.members[chosen],
-		Err:    err,
-	})
+		errTrace = append(errTrace, newExitEvent(g.members[chosen], err))
+		if !g.members[chosen].Optional() {
+			break
+		}
+
+		cases[chosen].Chan = reflect.Zero(waitChanType)
+		running--
+	}
 
 	return g.terminationSignal, errTrace
 }
 
-func (g *parallelGroup) stop(signal os.Signal, errTrace ErrorTrace) ErrorTrace {
-	errOccurred := false
-	exited := map[string]struct{}{}
-	if len(errTrace) > 0 {
-		for _, exitEvent := range errTrace {
-			exited[exitEvent.Member.Name] = struct{}{}
-			if exitEvent.Err != nil {
-				errOccurred = true
-			}
-		}
-	}
+func (g *parallelGroup) stop(signal os.Signal, errTrace ErrorTrace) error {
+	errOccurred := errTrace.requiredFailed()
+	exited := errTrace.memberNames()
 
-	cases := make([]reflect.SelectCase, 0, len(g.members))
-	liveMembers := make([]Member, 0, len(g.members))
+	tiers := map[int][]Member{}
+	priorities := []int{}
 	for _, member := range g.members {
 		if _, found := exited[member.Name]; found {
 			continue
 		}
-		if process, ok := g.pool[member.Name]; ok {
-			process.Signal(signal)
+		if _, ok := g.pool[member.Name]; !ok {
+			continue
+		}
+		priority := g.priorities[member.Name]
+		if _, ok := tiers[priority]; !ok {
+			priorities = append(priorities, priority)
+		}
+		tiers[priority] = append(tiers[priority], member)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
 
-			cases = append(cases, reflect.SelectCase{
-				Dir:  reflect.SelectRecv,
-				Chan: reflect.ValueOf(process.Wait()),
-			})
+	var order *rand.Rand
+	if g.deterministic {
+		order = rand.New(rand.NewSource(g.deterministicSeed))
+	}
 
-			liveMembers = append(liveMembers, member)
+	for _, priority := range priorities {
+		var tierErrOccurred bool
+		if order != nil {
+			errTrace, tierErrOccurred = g.stopTierInOrder(signal, tiers[priority], order, errTrace)
+		} else {
+			errTrace, tierErrOccurred = g.stopTier(signal, tiers[priority], errTrace)
 		}
+		if tierErrOccurred {
+			errOccurred = true
+		}
+	}
+
+	if errOccurred {
+		return errTrace
+	}
+
+	return nil
+}
+
+func (g *parallelGroup) stopTier(signal os.Signal, members []Member, errTrace ErrorTrace) (ErrorTrace, bool) {
+	errOccurred := false
+
+	cases := make([]reflect.SelectCase, 0, len(members))
+	for _, member := range members {
+		process := g.pool[member.Name]
+		process.Signal(signal)
+
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(process.Wait()),
+		})
 	}
 
 	numExited := 0
-	for {
+	for len(cases) > 0 {
 		chosen, recv, _ := reflect.Select(cases)
 		cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
 		recvError, _ := recv.Interface().(error)
 
-		errTrace = append(errTrace, ExitEvent{
-			Member: liveMembers[chosen],
-			Err:    recvError,
-		})
+		errTrace = append(errTrace, newExitEvent(members[chosen], recvError))
 
-		if recvError != nil {
+		if recvError != nil && !members[chosen].Optional() {
 			errOccurred = true
 		}
 
@@ -180,9 +419,38 @@ func (g *parallelGroup) stop(signal os.Signal, errTrace ErrorTrace) ErrorTrace {
 		}
 	}
 
-	if errOccurred {
-		return errTrace
+	return errTrace, errOccurred
+}
+
+// stopTierInOrder stops the members one at a time, in an order drawn from the
+// random source, for a deterministic group.
+func (g *parallelGroup) stopTierInOrder(signal os.Signal, members []Member, order *rand.Rand, errTrace ErrorTrace) (ErrorTrace, bool) {
+	errOccurred := false
+
+	for _, i := range order.Perm(len(members)) {
+		process := g.pool[members[i].Name]
+		process.Signal(signal)
+
+		err := <-process.Wait()
+		errTrace = append(errTrace, newExitEvent(members[i], err))
+
+		if err != nil && !members[i].Optional() {
+			errOccurred = true
+		}
 	}
 
-	return nil
+	return errTrace, errOccurred
+}
+
+/*
+ErrStartupTimeout is returned by a group whose member did not become ready
+within the group's StartupTimeout.
+*/
+type ErrStartupTimeout struct {
+	Member  string
+	Timeout time.Duration
+}
+
+func (e ErrStartupTimeout) Error() string {
+	return fmt.Sprintf("Timed out after %s waiting for %s to become ready", e.Timeout, e.Member)
 }
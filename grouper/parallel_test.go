@@ -3,6 +3,7 @@ package grouper_test
 import (
 	"errors"
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -50,6 +51,24 @@ var _ = Describe("Parallel Group", func() {
 		ginkgomon.Kill(groupProcess)
 	})
 
+	Describe("when the only member exits cleanly", func() {
+		BeforeEach(func() {
+			groupProcess = ifrit.Background(grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+			}))
+		})
+
+		It("exits with a nil error", func() {
+			childRunner1.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err == nil).Should(BeTrue())
+		})
+	})
+
 	Describe("Start", func() {
 		BeforeEach(func() {
 			groupProcess = ifrit.Background(groupRunner)
@@ -69,6 +88,28 @@ var _ = Describe("Parallel Group", func() {
 			Eventually(groupProcess.Ready()).Should(BeClosed())
 		})
 
+		Describe("when it receives a signal before all the runners are ready", func() {
+			It("signals and waits for every started runner", func() {
+				signal1 := childRunner1.WaitForCall()
+				childRunner1.TriggerReady()
+				signal2 := childRunner2.WaitForCall()
+				signal3 := childRunner3.WaitForCall()
+
+				groupProcess.Signal(syscall.SIGUSR2)
+
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+				Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+
+				childRunner1.TriggerExit(nil)
+				childRunner2.TriggerExit(nil)
+				Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+
+				childRunner3.TriggerExit(nil)
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
 		Describe("when all the runners are ready", func() {
 			var (
 				signal1 <-chan os.Signal
@@ -151,9 +192,9 @@ var _ = Describe("Parallel Group", func() {
 						var err error
 						Eventually(groupProcess.Wait()).Should(Receive(&err))
 						Ω(err).Should(ConsistOf(
-							grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil},
-							grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")},
-							grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil},
+							grouper.ExitEvent{Member: grouper.Member{"child1", childRunner1}, Err: nil},
+							grouper.ExitEvent{Member: grouper.Member{"child2", childRunner2}, Err: errors.New("Fail")},
+							grouper.ExitEvent{Member: grouper.Member{"child3", childRunner3}, Err: nil},
 						))
 					})
 				})
@@ -183,11 +224,353 @@ var _ = Describe("Parallel Group", func() {
 
 				Eventually(groupProcess.Wait()).Should(Receive(&err))
 				Ω(err).Should(ConsistOf(
-					grouper.ExitEvent{grouper.Member{"child2", childRunner2}, errors.New("Fail")},
-					grouper.ExitEvent{grouper.Member{"child1", childRunner1}, nil},
-					grouper.ExitEvent{grouper.Member{"child3", childRunner3}, nil},
+					grouper.ExitEvent{Member: grouper.Member{Name: "child2", Runner: childRunner2}, Err: grouper.StartupError{
+						Member: grouper.Member{Name: "child2", Runner: childRunner2},
+						Err:    errors.New("Fail"),
+					}},
+					grouper.ExitEvent{Member: grouper.Member{"child1", childRunner1}, Err: nil},
+					grouper.ExitEvent{Member: grouper.Member{"child3", childRunner3}, Err: nil},
 				))
 			})
+
+			It("reports the failure as a StartupError", func() {
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+
+				var startupErr grouper.StartupError
+				Ω(errors.As(err.(grouper.ErrorTrace)[0].Err, &startupErr)).Should(BeTrue())
+				Ω(startupErr.Member.Name).Should(Equal("child2"))
+				Ω(errors.Unwrap(startupErr)).Should(Equal(errors.New("Fail")))
+			})
+		})
+	})
+
+	Describe("Bounded start", func() {
+		BeforeEach(func() {
+			groupRunner = grouper.NewParallelBounded(os.Interrupt, members, 2)
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		It("starts no more than maxConcurrent members at a time", func() {
+			Eventually(childRunner1.RunCallCount).Should(Equal(1))
+			Eventually(childRunner2.RunCallCount).Should(Equal(1))
+			Consistently(childRunner3.RunCallCount, Δ).Should(BeZero())
+
+			childRunner1.TriggerReady()
+			Eventually(childRunner3.RunCallCount).Should(Equal(1))
+		})
+
+		It("becomes ready once every member is running", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("shuts down like a parallel group", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			signal3 := childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			groupProcess.Signal(syscall.SIGUSR2)
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+			Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+		})
+	})
+
+	Describe("Startup timeout", func() {
+		BeforeEach(func() {
+			groupRunner = grouper.NewParallelWithConfig(grouper.ParallelConfig{
+				TerminationSignal: os.Interrupt,
+				Members:           members,
+				StartupTimeout:    100 * time.Millisecond,
+			})
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		It("stops every member, and names the member which was not ready in time", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+			signal3 := childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+			Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(Equal(grouper.ErrStartupTimeout{Member: "child2", Timeout: 100 * time.Millisecond}))
+			Ω(groupProcess.Ready()).ShouldNot(BeClosed())
+		})
+
+		It("does not time out members which become ready in time", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			Consistently(groupProcess.Wait(), 200*time.Millisecond).ShouldNot(Receive())
+		})
+	})
+
+	Describe("Startup grace", func() {
+		var grace time.Duration
+
+		JustBeforeEach(func() {
+			groupRunner = grouper.NewParallelWithConfig(grouper.ParallelConfig{
+				TerminationSignal: os.Interrupt,
+				Members:           members,
+				MaxConcurrent:     2,
+				StartupGrace:      grace,
+			})
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		Context("when the starting members become ready within the grace", func() {
+			BeforeEach(func() {
+				grace = time.Second
+			})
+
+			It("signals them once they are ready, and starts no further members", func() {
+				signal1 := childRunner1.WaitForCall()
+				signal2 := childRunner2.WaitForCall()
+
+				groupProcess.Signal(syscall.SIGUSR2)
+				Consistently(signal1).ShouldNot(Receive())
+
+				childRunner1.TriggerReady()
+				Consistently(signal1).ShouldNot(Receive())
+				Ω(signal2).ShouldNot(Receive())
+				Ω(childRunner3.RunCallCount()).Should(BeZero())
+
+				childRunner2.TriggerReady()
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+				childRunner1.TriggerExit(nil)
+				childRunner2.TriggerExit(nil)
+
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+				Ω(childRunner3.RunCallCount()).Should(BeZero())
+				Consistently(signal1).ShouldNot(Receive())
+				Ω(signal2).ShouldNot(Receive())
+			})
+		})
+
+		Context("when a starting member is not ready within the grace", func() {
+			BeforeEach(func() {
+				grace = 100 * time.Millisecond
+			})
+
+			It("signals it once the grace has passed", func() {
+				signal1 := childRunner1.WaitForCall()
+				signal2 := childRunner2.WaitForCall()
+
+				groupProcess.Signal(syscall.SIGUSR2)
+				Consistently(signal2, grace/2).ShouldNot(Receive())
+
+				Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+				Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+				childRunner1.TriggerExit(nil)
+				childRunner2.TriggerExit(nil)
+
+				Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			})
+		})
+	})
+
+	Describe("Deterministic shutdown", func() {
+		runGroup := func(seed int64) ([]string, []string) {
+			var lock sync.Mutex
+			log := []string{}
+			record := func(entry string) {
+				lock.Lock()
+				defer lock.Unlock()
+				log = append(log, entry)
+			}
+
+			members := grouper.Members{}
+			for _, name := range []string{"a", "b", "c", "d", "e"} {
+				name := name
+				members = append(members, grouper.Member{
+					Name: name,
+					Runner: ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+						close(ready)
+						<-signals
+						record("signaled " + name)
+						time.Sleep(time.Millisecond)
+						record("exited " + name)
+						return errors.New(name)
+					}),
+				})
+			}
+
+			process := ifrit.Invoke(grouper.NewParallelWithConfig(grouper.ParallelConfig{
+				TerminationSignal: os.Interrupt,
+				Members:           members,
+				Deterministic:     true,
+				DeterministicSeed: seed,
+			}))
+			process.Signal(os.Interrupt)
+
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+
+			exits := []string{}
+			for _, exit := range err.(grouper.ErrorTrace) {
+				exits = append(exits, exit.Member.Name)
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			return exits, log
+		}
+
+		It("stops the members one at a time, in the same order for the same seed", func() {
+			exits, log := runGroup(42)
+			Ω(exits).Should(HaveLen(5))
+
+			expectedLog := []string{}
+			for _, name := range exits {
+				expectedLog = append(expectedLog, "signaled "+name, "exited "+name)
+			}
+			Ω(log).Should(Equal(expectedLog))
+
+			for i := 0; i < 3; i++ {
+				again, _ := runGroup(42)
+				Ω(again).Should(Equal(exits))
+			}
+		})
+	})
+
+	Describe("Parallel", func() {
+		BeforeEach(func() {
+			groupRunner = grouper.Parallel(os.Interrupt, childRunner1, childRunner2, childRunner3)
+			groupProcess = ifrit.Background(groupRunner)
+		})
+
+		It("runs the runners as a parallel group, naming them after their position", func() {
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			childRunner2.TriggerExit(errors.New("boom"))
+			Eventually(childRunner1.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+			Eventually(childRunner3.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+
+			names := map[string]error{}
+			for _, exit := range err.(grouper.ErrorTrace) {
+				names[exit.Member.Name] = exit.Err
+			}
+			Ω(names).Should(Equal(map[string]error{
+				"runner-0": nil,
+				"runner-1": errors.New("boom"),
+				"runner-2": nil,
+			}))
+		})
+	})
+
+	Describe("Optional members", func() {
+		BeforeEach(func() {
+			members[1].Runner = grouper.Optional(members[1].Runner)
+			groupRunner = grouper.NewParallel(os.Interrupt, members)
+			groupProcess = ifrit.Background(groupRunner)
+
+			childRunner1.TriggerReady()
+			childRunner2.TriggerReady()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+		})
+
+		It("keeps running when an optional member exits with an error", func() {
+			childRunner2.TriggerExit(errors.New("optional failure"))
+
+			Consistently(groupProcess.Wait()).ShouldNot(Receive())
+
+			groupProcess.Signal(syscall.SIGUSR2)
+			Eventually(childRunner1.WaitForCall()).Should(Receive(Equal(syscall.SIGUSR2)))
+			Eventually(childRunner3.WaitForCall()).Should(Receive(Equal(syscall.SIGUSR2)))
+			childRunner1.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("stops when a required member exits with an error", func() {
+			childRunner1.TriggerExit(errors.New("required failure"))
+
+			Eventually(childRunner2.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+			Eventually(childRunner3.WaitForCall()).Should(Receive(Equal(os.Interrupt)))
+			childRunner2.TriggerExit(nil)
+			childRunner3.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+			Ω(err.(grouper.ErrorTrace)[0].Member.Name).Should(Equal("child1"))
+		})
+	})
+
+	Describe("Shutdown priority", func() {
+		BeforeEach(func() {
+			members = grouper.Members{
+				{Name: "backend", Runner: childRunner1},
+				{Name: "frontend", Runner: childRunner2},
+				{Name: "api", Runner: childRunner3},
+			}
+
+			groupProcess = ifrit.Background(grouper.NewParallelWithConfig(grouper.ParallelConfig{
+				TerminationSignal:  os.Interrupt,
+				Members:            members,
+				ShutdownPriorities: map[string]int{"frontend": 2, "api": 1},
+			}))
+		})
+
+		It("stops each tier once the higher tiers have exited", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			signal2 := childRunner2.WaitForCall()
+			childRunner2.TriggerReady()
+			signal3 := childRunner3.WaitForCall()
+			childRunner3.TriggerReady()
+			Eventually(groupProcess.Ready()).Should(BeClosed())
+
+			groupProcess.Signal(syscall.SIGUSR2)
+
+			Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signal3, Δ).ShouldNot(Receive())
+			Ω(signal1).ShouldNot(Receive())
+			childRunner2.TriggerExit(nil)
+
+			Eventually(signal3).Should(Receive(Equal(syscall.SIGUSR2)))
+			Consistently(signal1, Δ).ShouldNot(Receive())
+			childRunner3.TriggerExit(nil)
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			childRunner1.TriggerExit(nil)
+
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
 		})
 	})
 })
@@ -0,0 +1,51 @@
+package grouper
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+stopPool shuts down the dynamic group in which another group runs its members.
+It closes the client, and signals each of the members in turn; if sequential is
+true, each member is waited for before the next is signaled.  Once the pool has
+exited, the remaining exits are appended to errTrace, which is returned if any
+member failed.
+*/
+func stopPool(
+	client DynamicClient,
+	poolProcess ifrit.Process,
+	exits <-chan ExitEvent,
+	members Members,
+	signal os.Signal,
+	sequential bool,
+	errTrace ErrorTrace,
+) error {
+	client.Close()
+
+	for _, member := range members {
+		p, ok := client.Get(member.Name)
+		if !ok {
+			continue
+		}
+		p.Signal(signal)
+		if sequential {
+			<-p.Wait()
+		}
+	}
+
+	<-poolProcess.Wait()
+
+	for exit := range exits {
+		errTrace = append(errTrace, exit)
+	}
+
+	for _, exit := range errTrace {
+		if exit.Err != nil {
+			return errTrace
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package grouper
+
+import (
+	"os"
+	"reflect"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewParallelQuorum starts its members simultaneously, like NewParallel, but is
+ready once quorum members are ready, rather than all of them.  The remaining
+members keep running, and may become ready later.
+
+Up to len(members)-quorum members may exit without becoming ready, and are
+reported in the group's ErrorTrace when it exits.  If more members than that
+fail to become ready, or any member exits after becoming ready, the group
+propogates the termination signal and exits.
+*/
+func NewParallelQuorum(terminationSignal os.Signal, members Members, quorum int) ifrit.Runner {
+	return quorumGroup{
+		parallelGroup: parallelGroup{
+			terminationSignal: terminationSignal,
+			pool:              make(map[string]ifrit.Process),
+			members:           members,
+			topology:          newTopology(),
+		},
+		quorum: quorum,
+	}
+}
+
+type quorumGroup struct {
+	parallelGroup
+	quorum int
+}
+
+func (g quorumGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	defer g.topology.setState(ifrit.StateExited)
+
+	err := g.validate()
+	if err != nil {
+		return err
+	}
+
+	numMembers := len(g.members)
+
+	quorum := g.quorum
+	if quorum > numMembers {
+		quorum = numMembers
+	}
+
+	processes := make([]ifrit.Process, numMembers)
+	cases := make([]reflect.SelectCase, 2*numMembers+1)
+
+	for i, member := range g.members {
+		process := ifrit.Background(member)
+		processes[i] = process
+		g.pool[member.Name] = process
+		g.topology.started(member, process)
+
+		cases[2*i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(process.Wait()),
+		}
+
+		cases[2*i+1] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(process.Ready()),
+		}
+	}
+
+	cases[2*numMembers] = reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(signals),
+	}
+
+	numReady := 0
+	numFailed := 0
+	errTrace := ErrorTrace{}
+
+	if numReady >= quorum {
+		close(ready)
+		g.topology.setState(ifrit.StateReady)
+	}
+
+	for {
+		chosen, recv, _ := reflect.Select(cases)
+
+		switch {
+		case chosen == 2*numMembers:
+			return g.stop(recv.Interface().(os.Signal), errTrace)
+
+		case chosen%2 == 0:
+			i := chosen / 2
+			recvError, _ := recv.Interface().(error)
+			errTrace = append(errTrace, newExitEvent(g.members[i], recvError))
+
+			select {
+			case <-processes[i].Ready():
+				return g.stop(g.terminationSignal, errTrace)
+			default:
+			}
+
+			cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
+			cases[chosen+1].Chan = reflect.Zero(cases[chosen+1].Chan.Type())
+
+			numFailed++
+			if numFailed > numMembers-quorum {
+				return g.stop(g.terminationSignal, errTrace)
+			}
+
+		default:
+			cases[chosen].Chan = reflect.Zero(cases[chosen].Chan.Type())
+			numReady++
+			if numReady == quorum {
+				close(ready)
+				g.topology.setState(ifrit.StateReady)
+			}
+		}
+	}
+}
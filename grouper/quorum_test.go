@@ -0,0 +1,97 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parallel Quorum Group", func() {
+	var (
+		groupProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		childRunner3 *fake_runner.TestRunner
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		childRunner3 = fake_runner.NewTestRunner()
+
+		groupProcess = ifrit.Background(grouper.NewParallelQuorum(os.Interrupt, grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
+		}, 2))
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		childRunner3.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	It("is ready once the quorum is ready, and keeps running slow members", func() {
+		childRunner1.TriggerReady()
+		Consistently(groupProcess.Ready(), Δ).ShouldNot(BeClosed())
+
+		childRunner3.TriggerReady()
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+
+		signal2 := childRunner2.WaitForCall()
+		Consistently(signal2, Δ).ShouldNot(Receive())
+
+		childRunner2.TriggerReady()
+		groupProcess.Signal(syscall.SIGUSR2)
+		Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+	})
+
+	It("tolerates members which fail to become ready, up to the quorum", func() {
+		childRunner1.TriggerReady()
+		childRunner2.TriggerExit(errors.New("unreachable"))
+		Consistently(groupProcess.Wait(), Δ).ShouldNot(Receive())
+
+		childRunner3.TriggerReady()
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+	})
+
+	It("fails when the quorum becomes unreachable", func() {
+		signal1 := childRunner1.WaitForCall()
+		childRunner1.TriggerReady()
+		childRunner2.TriggerExit(errors.New("unreachable"))
+		childRunner3.TriggerExit(errors.New("unreachable"))
+
+		Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+		childRunner1.TriggerExit(nil)
+
+		var err error
+		Eventually(groupProcess.Wait()).Should(Receive(&err))
+		Ω(err).Should(HaveLen(3))
+		Ω(groupProcess.Ready()).ShouldNot(BeClosed())
+	})
+
+	It("stops every member when a ready member exits", func() {
+		childRunner1.TriggerReady()
+		childRunner2.TriggerReady()
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+
+		signal2 := childRunner2.WaitForCall()
+		childRunner1.TriggerExit(errors.New("boom"))
+		Eventually(signal2).Should(Receive(Equal(os.Interrupt)))
+	})
+})
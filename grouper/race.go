@@ -0,0 +1,161 @@
+package grouper
+
+import (
+	"os"
+	"reflect"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewRace starts its members simultaneously, and races them to become ready.
+When the first member becomes ready, the remaining members are stopped with the
+termination signal, or os.Interrupt if it is nil, and once they have exited the
+race group becomes ready.  The group then runs until the winning member exits,
+returning its error.  Signals received by the group are forwarded to the winner.
+
+Members which exit before becoming ready are dropped from the race.  If every
+member exits without becoming ready, the group exits with an ErrorTrace of
+their exits, or nil if they all exited cleanly.
+*/
+func NewRace(terminationSignal os.Signal, members Members) ifrit.Runner {
+	if terminationSignal == nil {
+		terminationSignal = os.Interrupt
+	}
+
+	return raceGroup{
+		terminationSignal: terminationSignal,
+		members:           members,
+	}
+}
+
+type raceGroup struct {
+	terminationSignal os.Signal
+	members           Members
+}
+
+// GroupConfig reports the group's termination signal.
+func (g raceGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal}
+}
+
+func (g raceGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := g.members.Validate()
+	if err != nil {
+		return err
+	}
+
+	numMembers := len(g.members)
+	processes := make([]ifrit.Process, numMembers)
+	cases := make([]reflect.SelectCase, 2*numMembers+1)
+
+	for i, member := range g.members {
+		process := ifrit.Background(member)
+		processes[i] = process
+
+		cases[2*i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(process.Wait()),
+		}
+
+		cases[2*i+1] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(process.Ready()),
+		}
+	}
+
+	cases[2*numMembers] = reflect.SelectCase{
+		Dir:  reflect.SelectRecv,
+		Chan: reflect.ValueOf(signals),
+	}
+
+	errTrace := ErrorTrace{}
+	errOccurred := false
+	running := numMembers
+
+	for running > 0 {
+		chosen, recv, _ := reflect.Select(cases)
+
+		switch {
+		case chosen == 2*numMembers:
+			signal := recv.Interface().(os.Signal)
+			for i, process := range processes {
+				if !cases[2*i].Chan.IsNil() {
+					process.Signal(signal)
+				}
+			}
+			for i, process := range processes {
+				if cases[2*i].Chan.IsNil() {
+					continue
+				}
+				exitErr := <-process.Wait()
+				errTrace = append(errTrace, newExitEvent(g.members[i], exitErr))
+				if exitErr != nil {
+					errOccurred = true
+				}
+			}
+			running = 0
+
+		case chosen%2 == 0:
+			i := chosen / 2
+			recvError, _ := recv.Interface().(error)
+
+			select {
+			case <-processes[i].Ready():
+				g.stopLosers(processes, i, cases)
+				return recvError
+			default:
+			}
+
+			cases[2*i].Chan = reflect.Zero(cases[2*i].Chan.Type())
+			cases[2*i+1].Chan = reflect.Zero(cases[2*i+1].Chan.Type())
+			errTrace = append(errTrace, newExitEvent(g.members[i], recvError))
+			if recvError != nil {
+				errOccurred = true
+			}
+			running--
+
+		default:
+			i := chosen / 2
+			g.stopLosers(processes, i, cases)
+			close(ready)
+			return g.waitForWinner(processes[i], signals)
+		}
+	}
+
+	if errOccurred {
+		return errTrace
+	}
+
+	return nil
+}
+
+func (g raceGroup) stopLosers(processes []ifrit.Process, winner int, cases []reflect.SelectCase) {
+	for i, process := range processes {
+		if i == winner || cases[2*i].Chan.IsNil() {
+			continue
+		}
+		process.Signal(g.terminationSignal)
+	}
+
+	for i, process := range processes {
+		if i == winner || cases[2*i].Chan.IsNil() {
+			continue
+		}
+		<-process.Wait()
+	}
+}
+
+func (g raceGroup) waitForWinner(winner ifrit.Process, signals <-chan os.Signal) error {
+	exit := winner.Wait()
+
+	for {
+		select {
+		case signal := <-signals:
+			winner.Signal(signal)
+
+		case err := <-exit:
+			return err
+		}
+	}
+}
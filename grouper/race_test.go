@@ -0,0 +1,108 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Race Group", func() {
+	var (
+		groupProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		childRunner3 *fake_runner.TestRunner
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		childRunner3 = fake_runner.NewTestRunner()
+
+		groupProcess = ifrit.Background(grouper.NewRace(os.Interrupt, grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
+		}))
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		childRunner3.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	It("is ready once the first member is ready, and stops the others", func() {
+		signal1 := childRunner1.WaitForCall()
+		signal2 := childRunner2.WaitForCall()
+		signal3 := childRunner3.WaitForCall()
+
+		childRunner2.TriggerReady()
+
+		Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+		Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+		Consistently(groupProcess.Ready(), Δ).ShouldNot(BeClosed())
+
+		childRunner1.TriggerExit(nil)
+		childRunner3.TriggerExit(errors.New("cancelled"))
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+		Ω(signal2).ShouldNot(Receive())
+
+		groupProcess.Signal(syscall.SIGUSR2)
+		Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+
+		childRunner2.TriggerExit(errors.New("winner exited"))
+		Eventually(groupProcess.Wait()).Should(Receive(Equal(errors.New("winner exited"))))
+	})
+
+	It("moves on when a member fails before becoming ready", func() {
+		signal1 := childRunner1.WaitForCall()
+		childRunner2.WaitForCall()
+		signal3 := childRunner3.WaitForCall()
+
+		childRunner2.TriggerExit(errors.New("connection refused"))
+		Consistently(groupProcess.Ready(), Δ).ShouldNot(BeClosed())
+		Ω(signal1).ShouldNot(Receive())
+
+		childRunner1.TriggerReady()
+		Eventually(signal3).Should(Receive(Equal(os.Interrupt)))
+		childRunner3.TriggerExit(nil)
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+
+		childRunner1.TriggerExit(nil)
+		Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("returns every failure when no member becomes ready", func() {
+		childRunner1.WaitForCall()
+		childRunner2.WaitForCall()
+		childRunner3.WaitForCall()
+
+		childRunner1.TriggerExit(errors.New("boom1"))
+		childRunner2.TriggerExit(errors.New("boom2"))
+		childRunner3.TriggerExit(nil)
+
+		var err error
+		Eventually(groupProcess.Wait()).Should(Receive(&err))
+		Ω(err).Should(ConsistOf(
+			grouper.ExitEvent{Member: grouper.Member{Name: "child1", Runner: childRunner1}, Err: errors.New("boom1")},
+			grouper.ExitEvent{Member: grouper.Member{Name: "child2", Runner: childRunner2}, Err: errors.New("boom2")},
+			grouper.ExitEvent{Member: grouper.Member{Name: "child3", Runner: childRunner3}},
+		))
+		Ω(groupProcess.Ready()).ShouldNot(BeClosed())
+	})
+})
@@ -0,0 +1,111 @@
+package grouper
+
+import "sync"
+
+/*
+A GroupEvent is either an EntranceEvent or an ExitEvent.
+*/
+type GroupEvent interface {
+	String() string
+	groupEvent()
+}
+
+func (EntranceEvent) groupEvent() {}
+func (ExitEvent) groupEvent()     {}
+
+/*
+A Recorder captures the entrance and exit events of a dynamic group, for use in
+tests.  Attach the recorder to a group's client, run the group, and then call
+Events to get every event that occurred.
+
+Events are recorded in the order they were emitted, with the exception that an
+entrance event and an exit event emitted at nearly the same time may be recorded
+in either order.  A member's entrance event is always recorded before its exit
+event.
+*/
+type Recorder struct {
+	lock     *sync.Mutex
+	events   []GroupEvent
+	finished chan struct{}
+}
+
+/*
+NewRecorder creates a Recorder which has not been attached to a group.
+*/
+func NewRecorder() *Recorder {
+	return &Recorder{
+		lock:     new(sync.Mutex),
+		finished: make(chan struct{}),
+	}
+}
+
+/*
+Attach begins recording the events of the group the client belongs to.  Events
+emitted before Attach is called are recorded up to the group's event buffer
+size.  A Recorder may only be attached to one group.
+*/
+func (r *Recorder) Attach(client DynamicClient) {
+	entrances := client.EntranceListener()
+	exits := client.ExitListener()
+
+	go r.record(entrances, exits)
+}
+
+/*
+Events blocks until the attached group has finished, and returns all of the
+recorded events in order.
+*/
+func (r *Recorder) Events() []GroupEvent {
+	<-r.finished
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	events := make([]GroupEvent, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+func (r *Recorder) record(entrances <-chan EntranceEvent, exits <-chan ExitEvent) {
+	defer close(r.finished)
+
+	for entrances != nil || exits != nil {
+		select {
+		case entrance, ok := <-entrances:
+			if !ok {
+				entrances = nil
+				continue
+			}
+			r.append(entrance)
+
+		case exit, ok := <-exits:
+			if !ok {
+				exits = nil
+				continue
+			}
+			entrances = r.drainEntrances(entrances)
+			r.append(exit)
+		}
+	}
+}
+
+func (r *Recorder) drainEntrances(entrances <-chan EntranceEvent) <-chan EntranceEvent {
+	for {
+		select {
+		case entrance, ok := <-entrances:
+			if !ok {
+				return nil
+			}
+			r.append(entrance)
+		default:
+			return entrances
+		}
+	}
+}
+
+func (r *Recorder) append(event GroupEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.events = append(r.events, event)
+}
@@ -0,0 +1,89 @@
+package grouper_test
+
+import (
+	"errors"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		recorder    *grouper.Recorder
+		client      grouper.DynamicClient
+		poolProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+	)
+
+	eventNames := func(events []grouper.GroupEvent) []string {
+		names := []string{}
+		for _, event := range events {
+			names = append(names, event.String())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+
+		pool := grouper.NewDynamic(nil, 2, 2)
+		client = pool.Client()
+
+		recorder = grouper.NewRecorder()
+		recorder.Attach(client)
+
+		poolProcess = ifrit.Invoke(pool)
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		Eventually(poolProcess.Wait()).Should(Receive())
+	})
+
+	It("records the lifecycle of the group in order", func() {
+		exits := client.ExitListener()
+		entrances := client.EntranceListener()
+
+		client.Inserter() <- grouper.Member{"child1", childRunner1}
+		client.Inserter() <- grouper.Member{"child2", childRunner2}
+		client.Close()
+
+		childRunner2.TriggerReady()
+		Eventually(entrances).Should(Receive())
+		childRunner1.TriggerReady()
+		Eventually(entrances).Should(Receive())
+
+		childRunner2.TriggerExit(errors.New("boom"))
+		Eventually(exits).Should(Receive())
+		childRunner1.TriggerExit(nil)
+
+		Ω(eventNames(recorder.Events())).Should(Equal([]string{
+			"entrance: child2",
+			"entrance: child1",
+			"exit: child2 (err: boom)",
+			"exit: child1 (clean)",
+		}))
+	})
+
+	It("records a member's entrance before its exit, even when it fails to start", func() {
+		client.Inserter() <- grouper.Member{"child1", childRunner1}
+		client.Close()
+
+		childRunner1.TriggerExit(errors.New("boom"))
+
+		events := recorder.Events()
+		Ω(eventNames(events)).Should(Equal([]string{
+			"entrance: child1",
+			"exit: child1 (err: child1 failed before becoming ready: boom)",
+		}))
+		Ω(errors.Unwrap(events[1].(grouper.ExitEvent).Err)).Should(Equal(errors.New("boom")))
+	})
+})
@@ -0,0 +1,46 @@
+package grouper
+
+import (
+	"context"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+RunAndWait runs a group in the background, waits for it to become ready, and
+then waits for it to exit, returning its error.
+*/
+func RunAndWait(group ifrit.Runner) error {
+	return RunAndWaitContext(context.Background(), group)
+}
+
+/*
+RunAndWaitContext behaves as RunAndWait, but signals the group with os.Interrupt
+once the context is done, and then waits for the group to exit.
+*/
+func RunAndWaitContext(ctx context.Context, group ifrit.Runner) error {
+	return RunWithContext(ctx, group, os.Interrupt)
+}
+
+/*
+RunWithContext runs a runner, typically a group, until it exits, returning its
+error.  If the context is cancelled first, the runner is sent the given signal,
+and RunWithContext waits for it to exit.
+*/
+func RunWithContext(ctx context.Context, runner ifrit.Runner, signal os.Signal) error {
+	process := ifrit.Background(runner)
+	exit := process.Wait()
+	done := ctx.Done()
+
+	for {
+		select {
+		case <-done:
+			process.Signal(signal)
+			done = nil
+
+		case err := <-exit:
+			return err
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package grouper_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+	"github.com/tedsuo/ifrit/test_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunAndWait", func() {
+	var (
+		childRunner1 *fake_runner.TestRunner
+		recorder     *test_helpers.SignalRecoder
+		group        ifrit.Runner
+		errs         chan error
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		recorder = test_helpers.NewSignalRecorder()
+		group = grouper.NewParallel(os.Interrupt, grouper.Members{
+			{"child1", childRunner1},
+			{"recorder", recorder},
+		})
+		errs = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+	})
+
+	It("returns once the group completes", func() {
+		go func() {
+			errs <- grouper.RunAndWait(group)
+		}()
+
+		childRunner1.TriggerReady()
+		Consistently(errs).ShouldNot(Receive())
+
+		childRunner1.TriggerExit(nil)
+		Eventually(errs).Should(Receive(BeNil()))
+	})
+
+	It("returns the group's error", func() {
+		go func() {
+			errs <- grouper.RunAndWait(group)
+		}()
+
+		childRunner1.TriggerReady()
+		childRunner1.TriggerExit(errors.New("boom"))
+
+		var err error
+		Eventually(errs).Should(Receive(&err))
+		Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+	})
+
+	Describe("RunAndWaitContext", func() {
+		It("signals the group when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				errs <- grouper.RunAndWaitContext(ctx, group)
+			}()
+
+			signals := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			Consistently(errs).ShouldNot(Receive())
+
+			cancel()
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+
+			Eventually(errs).Should(Receive(BeNil()))
+			Ω(recorder.ReceivedSignals()).Should(ContainElement(os.Interrupt))
+		})
+	})
+
+	Describe("RunWithContext", func() {
+		BeforeEach(func() {
+			recorder = test_helpers.NewSignalRecorder(syscall.SIGUSR2)
+			group = grouper.NewParallel(os.Interrupt, grouper.Members{
+				{Name: "child1", Runner: childRunner1},
+				{Name: "recorder", Runner: recorder},
+			})
+		})
+
+		It("signals the runner with the given signal when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				errs <- grouper.RunWithContext(ctx, group, syscall.SIGUSR2)
+			}()
+
+			signals := childRunner1.WaitForCall()
+			childRunner1.TriggerReady()
+			Consistently(errs).ShouldNot(Receive())
+
+			cancel()
+			Eventually(signals).Should(Receive(Equal(syscall.SIGUSR2)))
+			childRunner1.TriggerExit(nil)
+
+			Eventually(errs).Should(Receive(BeNil()))
+			Ω(recorder.ReceivedSignals()).Should(ContainElement(syscall.SIGUSR2))
+		})
+
+		It("returns when the runner completes without the context being cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				errs <- grouper.RunWithContext(ctx, group, syscall.SIGUSR2)
+			}()
+
+			childRunner1.TriggerReady()
+			childRunner1.TriggerExit(errors.New("boom"))
+
+			Eventually(errs).Should(Receive(HaveOccurred()))
+			Ω(recorder.ReceivedSignals()).Should(ConsistOf(os.Interrupt))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package grouper
+
+import "strings"
+
+/*
+labelSelector is a parsed selector, as accepted by DynamicClient.Select: a
+comma-separated list of terms, all of which must match.  A "key=value" term
+matches a member whose label has that value, and a bare "key" term matches a
+member with that label, whatever its value.  An empty selector matches every
+member.
+*/
+type labelSelector []selectorTerm
+
+type selectorTerm struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func parseLabelSelector(selector string) labelSelector {
+	terms := labelSelector{}
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		parts := strings.SplitN(term, "=", 2)
+		selectorTerm := selectorTerm{key: strings.TrimSpace(parts[0])}
+		if len(parts) == 2 {
+			selectorTerm.value = strings.TrimSpace(parts[1])
+			selectorTerm.hasValue = true
+		}
+		terms = append(terms, selectorTerm)
+	}
+	return terms
+}
+
+func (s labelSelector) Matches(labels map[string]string) bool {
+	for _, term := range s {
+		value, ok := labels[term.key]
+		if !ok || (term.hasValue && value != term.value) {
+			return false
+		}
+	}
+	return true
+}
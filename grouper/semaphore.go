@@ -0,0 +1,44 @@
+package grouper
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A Semaphore limits how many members may run at once, across every group it is
+given to.  Pass the same Semaphore to several groups, through
+DynamicConfig.Semaphore or Limit, to share a single concurrency budget between
+them.
+*/
+type Semaphore struct {
+	tokens chan struct{}
+}
+
+/*
+NewSemaphore creates a Semaphore which allows n members to run at once.
+*/
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{tokens: make(chan struct{}, n)}
+}
+
+/*
+Limit wraps a Runner so that it only begins running once it has acquired a
+token from the semaphore, and releases the token when it exits.  If the runner
+is signaled while waiting for a token, it exits cleanly without running.
+*/
+func (s *Semaphore) Limit(runner ifrit.Runner) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		select {
+		case s.tokens <- struct{}{}:
+		case <-signals:
+			return nil
+		}
+		defer func() {
+			<-s.tokens
+		}()
+
+		return runner.Run(signals, ready)
+	})
+}
@@ -0,0 +1,68 @@
+package grouper_test
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Semaphore", func() {
+	var (
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+
+		process1 ifrit.Process
+		process2 ifrit.Process
+		exits2   <-chan grouper.ExitEvent
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+
+		semaphore := grouper.NewSemaphore(1)
+
+		pool1 := grouper.NewDynamicWithConfig(grouper.DynamicConfig{MaxCapacity: 1, EventBufferSize: 1, Semaphore: semaphore})
+		pool2 := grouper.NewDynamicWithConfig(grouper.DynamicConfig{MaxCapacity: 1, EventBufferSize: 1, Semaphore: semaphore})
+		process1 = ifrit.Invoke(pool1)
+		process2 = ifrit.Invoke(pool2)
+		exits2 = pool2.Client().ExitListener()
+
+		pool1.Client().Inserter() <- grouper.Member{Name: "child1", Runner: childRunner1}
+		Eventually(childRunner1.RunCallCount).Should(Equal(1))
+		pool2.Client().Inserter() <- grouper.Member{Name: "child2", Runner: childRunner2}
+	})
+
+	AfterEach(func() {
+		process1.Signal(os.Kill)
+		process2.Signal(os.Kill)
+		Eventually(func() bool {
+			childRunner1.EnsureExit()
+			childRunner2.EnsureExit()
+			return process1.State() == ifrit.StateExited && process2.State() == ifrit.StateExited
+		}).Should(BeTrue())
+	})
+
+	It("never runs members of groups sharing the semaphore concurrently", func() {
+		Consistently(childRunner2.RunCallCount).Should(BeZero())
+
+		childRunner1.TriggerExit(nil)
+		Eventually(childRunner2.RunCallCount).Should(Equal(1))
+	})
+
+	It("stops waiting for a token when the member is signaled", func() {
+		process2.Signal(os.Interrupt)
+
+		var exit grouper.ExitEvent
+		Eventually(exits2).Should(Receive(&exit))
+		Ω(exit.Member.Name).Should(Equal("child2"))
+		Ω(exit.Err).ShouldNot(HaveOccurred())
+		Eventually(process2.Wait()).Should(Receive())
+		Ω(childRunner2.RunCallCount()).Should(BeZero())
+	})
+})
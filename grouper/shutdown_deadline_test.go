@@ -0,0 +1,104 @@
+package grouper_test
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+	"github.com/tedsuo/ifrit/test_helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type hangingRunner struct {
+	lock     sync.Mutex
+	signals  []os.Signal
+	released chan struct{}
+	release  sync.Once
+}
+
+func newHangingRunner() *hangingRunner {
+	return &hangingRunner{released: make(chan struct{})}
+}
+
+func (r *hangingRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+	for {
+		select {
+		case signal := <-signals:
+			r.lock.Lock()
+			r.signals = append(r.signals, signal)
+			r.lock.Unlock()
+		case <-r.released:
+			return nil
+		}
+	}
+}
+
+func (r *hangingRunner) Release() {
+	r.release.Do(func() {
+		close(r.released)
+	})
+}
+
+func (r *hangingRunner) ReceivedSignals() []os.Signal {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]os.Signal{}, r.signals...)
+}
+
+var _ = Describe("Shutdown deadline", func() {
+	var (
+		hanging     *hangingRunner
+		behaved     *test_helpers.SignalRecoder
+		poolProcess ifrit.Process
+		entrances   <-chan grouper.EntranceEvent
+	)
+
+	BeforeEach(func() {
+		hanging = newHangingRunner()
+		behaved = test_helpers.NewSignalRecorder()
+
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity:      2,
+			EventBufferSize:  2,
+			ShutdownDeadline: 100 * time.Millisecond,
+			EscalationSignal: os.Kill,
+		})
+		client := pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+		entrances = client.EntranceListener()
+
+		client.Inserter() <- grouper.Member{Name: "behaved", Runner: behaved}
+		client.Inserter() <- grouper.Member{Name: "hanging", Runner: hanging}
+		Eventually(entrances).Should(Receive())
+		Eventually(entrances).Should(Receive())
+	})
+
+	AfterEach(func() {
+		hanging.Release()
+	})
+
+	It("escalates, then gives up on members which do not exit", func() {
+		poolProcess.Signal(os.Interrupt)
+
+		Eventually(hanging.ReceivedSignals).Should(Equal([]os.Signal{os.Interrupt}))
+		Eventually(hanging.ReceivedSignals).Should(Equal([]os.Signal{os.Interrupt, os.Kill}))
+
+		var err error
+		Eventually(poolProcess.Wait()).Should(Receive(&err))
+		Ω(err).Should(Equal(grouper.ErrShutdownDeadlineExceeded{Members: []string{"hanging"}}))
+		Ω(behaved.ReceivedSignals()).Should(Equal([]os.Signal{os.Interrupt}))
+	})
+
+	It("exits normally when every member exits in time", func() {
+		poolProcess.Signal(os.Interrupt)
+		Eventually(hanging.ReceivedSignals).Should(ContainElement(os.Interrupt))
+		hanging.Release()
+
+		Eventually(poolProcess.Wait()).Should(Receive(BeNil()))
+	})
+})
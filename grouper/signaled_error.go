@@ -0,0 +1,66 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+SignaledError is returned by a group wrapped with WithSignaledError when it
+exited cleanly because it was signaled.
+*/
+type SignaledError struct {
+	Signal os.Signal
+}
+
+func (e SignaledError) Error() string {
+	return fmt.Sprintf("Group stopped by signal: %s", e.Signal)
+}
+
+/*
+WithSignaledError wraps a group so that callers can tell why it stopped.  If
+the group was signaled and every member exited cleanly, the wrapper returns a
+SignaledError carrying the first signal received, rather than nil.  If a member
+failed, the group's error, typically an ErrorTrace, is returned unchanged.  A
+group which exits cleanly without being signaled still returns nil.
+
+Because a signaled group no longer returns nil, a wrapped group should not be
+nested as the member of another group.
+*/
+func WithSignaledError(group ifrit.Runner) ifrit.Runner {
+	return signaledErrorGroup{group: group}
+}
+
+type signaledErrorGroup struct {
+	group ifrit.Runner
+}
+
+func (g signaledErrorGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	process := ifrit.Background(g.group)
+	processReady := process.Ready()
+	exit := process.Wait()
+
+	var received os.Signal
+
+	for {
+		select {
+		case signal := <-signals:
+			if received == nil {
+				received = signal
+			}
+			process.Signal(signal)
+
+		case <-processReady:
+			processReady = nil
+			close(ready)
+
+		case err := <-exit:
+			if err == nil && received != nil {
+				return SignaledError{Signal: received}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithSignaledError", func() {
+	var (
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		members      grouper.Members
+		groupProcess ifrit.Process
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		members = grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+		}
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		ginkgomon.Kill(groupProcess)
+	})
+
+	for _, group := range []struct {
+		name string
+		new  func(grouper.Members) ifrit.Runner
+	}{
+		{"parallel", func(members grouper.Members) ifrit.Runner { return grouper.NewParallel(os.Interrupt, members) }},
+		{"ordered", func(members grouper.Members) ifrit.Runner { return grouper.NewOrdered(os.Interrupt, members) }},
+	} {
+		group := group
+
+		Context("wrapping a "+group.name+" group", func() {
+			BeforeEach(func() {
+				groupProcess = ifrit.Background(grouper.WithSignaledError(group.new(members)))
+				childRunner1.TriggerReady()
+				childRunner2.TriggerReady()
+				Eventually(groupProcess.Ready()).Should(BeClosed())
+			})
+
+			It("returns a SignaledError when stopped by a signal", func() {
+				groupProcess.Signal(syscall.SIGUSR2)
+				childRunner1.TriggerExit(nil)
+				childRunner2.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+
+				var signaled grouper.SignaledError
+				Ω(errors.As(err, &signaled)).Should(BeTrue())
+				Ω(signaled.Signal).Should(Equal(syscall.SIGUSR2))
+			})
+
+			It("returns the ErrorTrace when a member fails", func() {
+				childRunner2.TriggerExit(errors.New("boom"))
+				childRunner1.TriggerExit(nil)
+
+				var err error
+				Eventually(groupProcess.Wait()).Should(Receive(&err))
+
+				var trace grouper.ErrorTrace
+				Ω(errors.As(err, &trace)).Should(BeTrue())
+				var signaled grouper.SignaledError
+				Ω(errors.As(err, &signaled)).Should(BeFalse())
+			})
+		})
+	}
+})
@@ -1,35 +1,41 @@
 package grouper
 
-import "container/list"
-
+/*
+slidingBuffer is a fixed size ring buffer.  Once it is full, each Append
+overwrites the oldest item in place.
+*/
 type slidingBuffer struct {
-	buffer   *list.List
-	capacity int
+	items  []interface{}
+	start  int
+	length int
 }
 
-func newSlidingBuffer(capacity int) slidingBuffer {
-	return slidingBuffer{list.New(), capacity}
+func newSlidingBuffer(capacity int) *slidingBuffer {
+	return &slidingBuffer{items: make([]interface{}, capacity)}
 }
 
-func (b slidingBuffer) Append(item interface{}) {
-	if b.capacity == 0 {
+func (b *slidingBuffer) Append(item interface{}) {
+	capacity := len(b.items)
+	if capacity == 0 {
 		return
 	}
 
-	b.buffer.PushBack(item)
-	if b.buffer.Len() > b.capacity {
-		b.buffer.Remove(b.buffer.Front())
+	if b.length < capacity {
+		b.items[(b.start+b.length)%capacity] = item
+		b.length++
+		return
 	}
+
+	b.items[b.start] = item
+	b.start = (b.start + 1) % capacity
 }
 
-func (b slidingBuffer) Range(callback func(item interface{})) {
-	elem := b.buffer.Front()
-	for elem != nil {
-		callback(elem.Value)
-		elem = elem.Next()
+func (b *slidingBuffer) Range(callback func(item interface{})) {
+	for i := 0; i < b.length; i++ {
+		callback(b.items[(b.start+i)%len(b.items)])
 	}
 }
 
-func (b slidingBuffer) Length() int {
-	return b.buffer.Len()
+func (b *slidingBuffer) Length() int {
+	return b.length
 }
@@ -1,6 +1,8 @@
 package grouper
 
 import (
+	"testing"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -8,11 +10,26 @@ import (
 const capacity = 3
 
 var _ = Describe("Sliding Buffer", func() {
-	var buffer slidingBuffer
+	var buffer *slidingBuffer
 	BeforeEach(func() {
 		buffer = newSlidingBuffer(capacity)
 	})
 
+	Context("when the number of appends is within capacity", func() {
+		BeforeEach(func() {
+			buffer.Append(0)
+			buffer.Append(1)
+		})
+
+		It("Range returns every item, oldest first", func() {
+			items := []interface{}{}
+			buffer.Range(func(item interface{}) {
+				items = append(items, item)
+			})
+			Ω(items).Should(Equal([]interface{}{0, 1}))
+		})
+	})
+
 	Context("when the number of appends exceeds capacity", func() {
 		BeforeEach(func() {
 			for i := 0; i < capacity*2; i++ {
@@ -32,5 +49,32 @@ var _ = Describe("Sliding Buffer", func() {
 				expectedIndex++
 			})
 		})
+
+		It("does not allocate when appending", func() {
+			var item interface{} = ExitEvent{}
+			Ω(testing.AllocsPerRun(100, func() {
+				buffer.Append(item)
+			})).Should(BeZero())
+		})
+	})
+
+	Context("when the capacity is zero", func() {
+		It("retains nothing", func() {
+			buffer = newSlidingBuffer(0)
+			buffer.Append(1)
+			Ω(buffer.Length()).Should(BeZero())
+		})
 	})
 })
+
+func BenchmarkSlidingBufferAppend(b *testing.B) {
+	buffer := newSlidingBuffer(100)
+	var item interface{} = ExitEvent{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buffer.Append(item)
+	}
+}
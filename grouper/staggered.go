@@ -0,0 +1,117 @@
+package grouper
+
+import (
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewStaggered starts its members one at a time, waiting delay between each, but
+without waiting for a member to become ready before starting the next.  Use a
+staggered group to avoid a thundering herd of members starting against a shared
+backend.  The group becomes ready once every member is ready.
+
+Like a parallel group, if a member exits, or the group is signaled, no further
+members are started, and the running members are stopped.  A nil termination
+signal defaults to os.Interrupt.
+*/
+func NewStaggered(terminationSignal os.Signal, members []Member, delay time.Duration) ifrit.Runner {
+	if terminationSignal == nil {
+		terminationSignal = os.Interrupt
+	}
+
+	return staggeredGroup{
+		terminationSignal: terminationSignal,
+		members:           members,
+		delay:             delay,
+	}
+}
+
+type staggeredGroup struct {
+	terminationSignal os.Signal
+	members           Members
+	delay             time.Duration
+}
+
+// GroupConfig reports the group's termination signal.
+func (g staggeredGroup) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{TerminationSignal: g.terminationSignal}
+}
+
+func (g staggeredGroup) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := g.members.Validate()
+	if err != nil {
+		return err
+	}
+
+	pool := NewDynamic(nil, len(g.members), len(g.members))
+	client := pool.Client()
+	poolProcess := ifrit.Background(pool)
+
+	entrances := client.EntranceListener()
+	exits := client.ExitListener()
+
+	var next <-chan time.Time
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	numStarted := 0
+	startNext := func() {
+		client.Inserter() <- g.members[numStarted]
+		numStarted++
+
+		next = nil
+		if numStarted < len(g.members) {
+			timer = time.NewTimer(g.delay)
+			next = timer.C
+		}
+	}
+
+	if len(g.members) == 0 {
+		close(ready)
+		ready = nil
+	} else {
+		startNext()
+	}
+
+	numReady := 0
+	for {
+		select {
+		case signal := <-signals:
+			return g.stop(client, poolProcess, exits, signal, ErrorTrace{})
+
+		case <-next:
+			startNext()
+
+		case entrance := <-entrances:
+			select {
+			case <-entrance.Process.Ready():
+			default:
+				continue
+			}
+			numReady++
+			if numReady == len(g.members) {
+				close(ready)
+			}
+
+		case exit := <-exits:
+			return g.stop(client, poolProcess, exits, g.terminationSignal, ErrorTrace{exit})
+		}
+	}
+}
+
+func (g staggeredGroup) stop(
+	client DynamicClient,
+	poolProcess ifrit.Process,
+	exits <-chan ExitEvent,
+	signal os.Signal,
+	errTrace ErrorTrace,
+) error {
+	return stopPool(client, poolProcess, exits, g.members, signal, false, errTrace)
+}
@@ -0,0 +1,103 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Staggered Group", func() {
+	var (
+		groupProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		childRunner3 *fake_runner.TestRunner
+
+		delay = 100 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		childRunner3 = fake_runner.NewTestRunner()
+
+		groupProcess = ifrit.Background(grouper.NewStaggered(os.Interrupt, grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
+		}, delay))
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		childRunner3.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	It("starts each member after the delay, without waiting for readiness", func() {
+		started := time.Now()
+		childRunner1.WaitForCall()
+		Consistently(childRunner2.RunCallCount, delay/2).Should(BeZero())
+
+		childRunner2.WaitForCall()
+		Ω(time.Since(started)).Should(BeNumerically(">=", delay))
+		Ω(childRunner3.RunCallCount()).Should(BeZero())
+
+		childRunner3.WaitForCall()
+		Ω(time.Since(started)).Should(BeNumerically(">=", 2*delay))
+	})
+
+	It("becomes ready once every member is ready", func() {
+		childRunner1.WaitForCall()
+		childRunner1.TriggerReady()
+		childRunner2.WaitForCall()
+		childRunner2.TriggerReady()
+		Consistently(groupProcess.Ready(), delay/2).ShouldNot(BeClosed())
+
+		childRunner3.WaitForCall()
+		childRunner3.TriggerReady()
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+	})
+
+	Context("when signaled before every member has started", func() {
+		It("stops starting members, and stops the running members", func() {
+			signal1 := childRunner1.WaitForCall()
+			groupProcess.Signal(syscall.SIGUSR2)
+
+			Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+			childRunner1.TriggerExit(nil)
+
+			Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+			Consistently(childRunner2.RunCallCount, 2*delay).Should(BeZero())
+		})
+	})
+
+	Context("when a member exits", func() {
+		It("stops the running members with the termination signal, and returns the exits", func() {
+			signal1 := childRunner1.WaitForCall()
+			childRunner2.WaitForCall()
+			childRunner2.TriggerExit(errors.New("boom"))
+
+			Eventually(signal1).Should(Receive(Equal(os.Interrupt)))
+			childRunner1.TriggerExit(nil)
+
+			var err error
+			Eventually(groupProcess.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+			Ω(err.(grouper.ErrorTrace)).Should(HaveLen(2))
+			Ω(childRunner3.RunCallCount()).Should(BeZero())
+		})
+	})
+})
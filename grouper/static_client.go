@@ -0,0 +1,86 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+)
+
+/*
+StaticClient controls a running ordered group.
+*/
+type StaticClient interface {
+
+	/*
+	   RestartFrom restarts the named member, along with every member after it,
+	   since later members may depend upon it. Those members are stopped in
+	   reverse order with the group's termination signal, or os.Interrupt, and
+	   then started again in order, each waiting for the previous to become
+	   ready. Members before the named member are untouched, and the exits of
+	   the stopped members are not reported.
+
+	   It blocks until the group is ready and the restart has finished. It
+	   returns ErrMemberNotFound if the member is not present, or the group has
+	   exited. If a member exits before becoming ready, it returns a
+	   StartupError, and the group shuts down as it would for any exit. If the
+	   group is signaled during the restart, it returns ErrRestartInterrupted.
+	*/
+	RestartFrom(name string) error
+
+	/*
+	   ReadyAll provides a channel which is closed once every member of the group
+	   has become ready, at the same moment as the group's own ready channel. It
+	   may be called at any time, and returns the same closed channel once the
+	   group is ready. The channel is closed only once, and stays closed while
+	   members are restarted by RestartFrom. It is never closed if the group
+	   exits before every member is ready.
+	*/
+	ReadyAll() <-chan struct{}
+}
+
+/*
+ErrRestartInterrupted is returned by RestartFrom when the group is signaled
+before the restarted members have become ready.
+*/
+type ErrRestartInterrupted struct {
+	Signal os.Signal
+}
+
+func (e ErrRestartInterrupted) Error() string {
+	return fmt.Sprintf("Restart interrupted by signal: %s", e.Signal)
+}
+
+type restartRequest struct {
+	Name     string
+	Response chan error
+}
+
+type staticClient struct {
+	restartChannel   chan restartRequest
+	readyNotifier    chan struct{}
+	completeNotifier chan struct{}
+}
+
+func newStaticClient() staticClient {
+	return staticClient{
+		restartChannel:   make(chan restartRequest),
+		readyNotifier:    make(chan struct{}),
+		completeNotifier: make(chan struct{}),
+	}
+}
+
+func (c staticClient) RestartFrom(name string) error {
+	req := restartRequest{
+		Name:     name,
+		Response: make(chan error, 1),
+	}
+	select {
+	case c.restartChannel <- req:
+		return <-req.Response
+	case <-c.completeNotifier:
+		return ErrMemberNotFound{name}
+	}
+}
+
+func (c staticClient) ReadyAll() <-chan struct{} {
+	return c.readyNotifier
+}
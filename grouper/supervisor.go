@@ -0,0 +1,250 @@
+package grouper
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A SupervisionStrategy determines which members a supervisor restarts when a
+member exits with an error.
+*/
+type SupervisionStrategy int
+
+const (
+	// OneForOne restarts only the member which failed.
+	OneForOne SupervisionStrategy = iota
+
+	// OneForAll stops every running member, then restarts them all along with
+	// the member which failed.
+	OneForAll
+)
+
+/*
+A SupervisionPolicy configures a supervisor.
+
+If more than MaxRestarts restarts would occur within Period, the supervisor
+gives up, stops its members, and exits with ErrRestartIntensityExceeded.  A
+MaxRestarts of zero defaults to DefaultMaxRestarts, and a negative MaxRestarts
+allows no restarts at all.  A Period of zero places no limit on restarts.
+
+Signal is sent to members being stopped by a OneForAll restart. It defaults to
+os.Interrupt.
+*/
+type SupervisionPolicy struct {
+	Strategy    SupervisionStrategy
+	MaxRestarts int
+	Period      time.Duration
+	Signal      os.Signal
+}
+
+// DefaultMaxRestarts is the MaxRestarts of a SupervisionPolicy which sets none.
+const DefaultMaxRestarts = 3
+
+/*
+NewSupervisor starts its members simultaneously, and keeps them running by
+restarting any member which exits with an error, according to the policy.
+Members which exit cleanly are not restarted, and the supervisor exits once no
+members remain.  Because members are restarted by running them again, every
+member's Runner must be safe to Run more than once.
+
+The supervisor becomes ready once every member has become ready, or exited
+cleanly before becoming ready.
+*/
+func NewSupervisor(members []Member, policy SupervisionPolicy) ifrit.Runner {
+	if policy.Signal == nil {
+		policy.Signal = os.Interrupt
+	}
+	if policy.MaxRestarts == 0 {
+		policy.MaxRestarts = DefaultMaxRestarts
+	}
+
+	return &supervisor{
+		members: members,
+		policy:  policy,
+	}
+}
+
+type supervisor struct {
+	members Members
+	policy  SupervisionPolicy
+
+	client      DynamicClient
+	poolProcess ifrit.Process
+	restarts    []time.Time
+}
+
+func (s *supervisor) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := s.members.Validate()
+	if err != nil {
+		return err
+	}
+
+	pool := NewDynamic(nil, len(s.members), 2*len(s.members))
+	s.client = pool.Client()
+	s.poolProcess = ifrit.Background(pool)
+	s.restarts = nil
+
+	entrances := s.client.EntranceListener()
+	exits := s.client.ExitListener()
+
+	s.insert(s.members)
+	running := len(s.members)
+
+	entered := map[string]struct{}{}
+	stopping := map[string]struct{}{}
+	var restartAll Members
+
+	if len(s.members) == 0 {
+		close(ready)
+		ready = nil
+	}
+
+	// A member counts towards readiness once it is ready, or has exited
+	// cleanly without becoming ready, as it will not be restarted.
+	markEntered := func(name string) {
+		if ready == nil {
+			return
+		}
+		entered[name] = struct{}{}
+		if len(entered) == len(s.members) {
+			close(ready)
+			ready = nil
+		}
+	}
+
+	for {
+		select {
+		case signal := <-signals:
+			return s.stop(signal, exits)
+
+		case entrance := <-entrances:
+			if ready == nil {
+				continue
+			}
+			select {
+			case <-entrance.Process.Ready():
+			default:
+				continue
+			}
+			markEntered(entrance.Member.Name)
+
+		case exit := <-exits:
+			running--
+
+			if _, ok := stopping[exit.Member.Name]; ok {
+				delete(stopping, exit.Member.Name)
+				if len(stopping) == 0 {
+					s.insert(restartAll)
+					running += len(restartAll)
+					restartAll = nil
+				}
+				continue
+			}
+
+			if exit.Err == nil {
+				markEntered(exit.Member.Name)
+				if running == 0 && len(stopping) == 0 {
+					return s.stop(s.policy.Signal, exits)
+				}
+				continue
+			}
+
+			if !s.allowRestart() {
+				s.stop(s.policy.Signal, exits)
+				return ErrRestartIntensityExceeded{
+					MaxRestarts: s.policy.MaxRestarts,
+					Period:      s.policy.Period,
+					Exit:        exit,
+				}
+			}
+
+			switch s.policy.Strategy {
+			case OneForAll:
+				// A restart may already be under way, if another member failed
+				// while the rest were stopping; its members are kept.
+				for _, member := range s.members {
+					if restartAll.contains(member.Name) {
+						continue
+					}
+					if member.Name == exit.Member.Name {
+						restartAll = append(restartAll, member)
+						continue
+					}
+					if p, ok := s.client.Get(member.Name); ok {
+						stopping[member.Name] = struct{}{}
+						restartAll = append(restartAll, member)
+						p.Signal(s.policy.Signal)
+					}
+				}
+
+				if len(stopping) == 0 {
+					s.insert(restartAll)
+					running += len(restartAll)
+					restartAll = nil
+				}
+
+			default:
+				s.insert(Members{exit.Member})
+				running++
+			}
+		}
+	}
+}
+
+func (m Members) contains(name string) bool {
+	for _, member := range m {
+		if member.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *supervisor) insert(members Members) {
+	insert := s.client.Inserter()
+	for _, member := range members {
+		insert <- member
+	}
+}
+
+func (s *supervisor) allowRestart() bool {
+	now := time.Now()
+
+	recent := s.restarts[:0]
+	for _, restart := range s.restarts {
+		if now.Sub(restart) < s.policy.Period {
+			recent = append(recent, restart)
+		}
+	}
+	s.restarts = recent
+
+	if len(s.restarts) >= s.policy.MaxRestarts {
+		return false
+	}
+
+	s.restarts = append(s.restarts, now)
+	return true
+}
+
+func (s *supervisor) stop(signal os.Signal, exits <-chan ExitEvent) error {
+	return stopPool(s.client, s.poolProcess, exits, s.members, signal, false, ErrorTrace{})
+}
+
+/*
+ErrRestartIntensityExceeded is returned by a supervisor which has stopped
+because its members failed more than MaxRestarts times within Period. Exit is
+the failure which exceeded the limit.
+*/
+type ErrRestartIntensityExceeded struct {
+	MaxRestarts int
+	Period      time.Duration
+	Exit        ExitEvent
+}
+
+func (e ErrRestartIntensityExceeded) Error() string {
+	return fmt.Sprintf("Exceeded %d restarts in %s: %s", e.MaxRestarts, e.Period, e.Exit)
+}
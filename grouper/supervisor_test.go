@@ -0,0 +1,208 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type restartableRunner struct {
+	runs     int32
+	signaled int32
+	fail     chan error
+}
+
+func newRestartableRunner() *restartableRunner {
+	return &restartableRunner{fail: make(chan error)}
+}
+
+func (r *restartableRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	atomic.AddInt32(&r.runs, 1)
+	close(ready)
+
+	select {
+	case err := <-r.fail:
+		return err
+	case <-signals:
+		atomic.AddInt32(&r.signaled, 1)
+		return nil
+	}
+}
+
+func (r *restartableRunner) Runs() int32 {
+	return atomic.LoadInt32(&r.runs)
+}
+
+func (r *restartableRunner) Signaled() int32 {
+	return atomic.LoadInt32(&r.signaled)
+}
+
+var _ = Describe("Supervisor", func() {
+	var (
+		runner1, runner2, runner3 *restartableRunner
+		members                   grouper.Members
+		policy                    grouper.SupervisionPolicy
+		process                   ifrit.Process
+
+		Δ time.Duration = 10 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		runner1 = newRestartableRunner()
+		runner2 = newRestartableRunner()
+		runner3 = newRestartableRunner()
+
+		members = grouper.Members{
+			{"child1", runner1},
+			{"child2", runner2},
+			{"child3", runner3},
+		}
+
+		policy = grouper.SupervisionPolicy{
+			MaxRestarts: 5,
+			Period:      time.Minute,
+		}
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(grouper.NewSupervisor(members, policy))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("becomes ready once every member is ready", func() {
+		Ω(process.Ready()).Should(BeClosed())
+		Ω(runner1.Runs()).Should(Equal(int32(1)))
+		Ω(runner2.Runs()).Should(Equal(int32(1)))
+		Ω(runner3.Runs()).Should(Equal(int32(1)))
+	})
+
+	Context("with the one-for-one strategy", func() {
+		BeforeEach(func() {
+			policy.Strategy = grouper.OneForOne
+		})
+
+		It("restarts only the failed member", func() {
+			runner2.fail <- errors.New("boom")
+
+			Eventually(runner2.Runs).Should(Equal(int32(2)))
+			Consistently(runner1.Runs, Δ).Should(Equal(int32(1)))
+			Ω(runner1.Signaled()).Should(BeZero())
+			Ω(runner3.Signaled()).Should(BeZero())
+		})
+
+		It("does not restart a member which exits cleanly", func() {
+			runner2.fail <- nil
+
+			Consistently(runner2.Runs, Δ).Should(Equal(int32(1)))
+			Consistently(process.Wait(), Δ).ShouldNot(Receive())
+		})
+	})
+
+	Context("with the one-for-all strategy", func() {
+		BeforeEach(func() {
+			policy.Strategy = grouper.OneForAll
+		})
+
+		It("stops and restarts every member when one fails", func() {
+			runner2.fail <- errors.New("boom")
+
+			Eventually(runner1.Signaled).Should(Equal(int32(1)))
+			Eventually(runner3.Signaled).Should(Equal(int32(1)))
+
+			Eventually(runner1.Runs).Should(Equal(int32(2)))
+			Eventually(runner2.Runs).Should(Equal(int32(2)))
+			Eventually(runner3.Runs).Should(Equal(int32(2)))
+			Consistently(process.Wait(), Δ).ShouldNot(Receive())
+		})
+
+		It("restarts every member once when two fail together", func() {
+			runner2.fail <- errors.New("boom")
+			runner3.fail <- errors.New("boom")
+
+			Eventually(runner1.Runs).Should(Equal(int32(2)))
+			Eventually(runner2.Runs).Should(Equal(int32(2)))
+			Eventually(runner3.Runs).Should(Equal(int32(2)))
+			Consistently(runner1.Runs, Δ).Should(Equal(int32(2)))
+			Consistently(runner2.Runs, Δ).Should(Equal(int32(2)))
+			Consistently(runner3.Runs, Δ).Should(Equal(int32(2)))
+		})
+	})
+
+	Context("when a member exits cleanly before becoming ready", func() {
+		BeforeEach(func() {
+			members[1].Runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				return nil
+			})
+		})
+
+		It("becomes ready once the other members are ready", func() {
+			Eventually(process.Ready()).Should(BeClosed())
+			Consistently(process.Wait(), Δ).ShouldNot(Receive())
+		})
+	})
+
+	Context("without MaxRestarts", func() {
+		BeforeEach(func() {
+			policy.MaxRestarts = 0
+		})
+
+		It("allows DefaultMaxRestarts restarts", func() {
+			for i := 1; i <= grouper.DefaultMaxRestarts; i++ {
+				runner2.fail <- errors.New("boom")
+				Eventually(runner2.Runs).Should(Equal(int32(i + 1)))
+			}
+
+			runner2.fail <- errors.New("boom")
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrRestartIntensityExceeded{}))
+		})
+	})
+
+	Context("when the restart intensity is exceeded", func() {
+		BeforeEach(func() {
+			policy.Strategy = grouper.OneForOne
+			policy.MaxRestarts = 1
+		})
+
+		It("stops every member and exits with an error", func() {
+			runner2.fail <- errors.New("boom")
+			Eventually(runner2.Runs).Should(Equal(int32(2)))
+
+			runner2.fail <- errors.New("boom again")
+
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrRestartIntensityExceeded{}))
+			Ω(err.(grouper.ErrRestartIntensityExceeded).Exit.Err).Should(Equal(errors.New("boom again")))
+
+			Ω(runner1.Signaled()).Should(Equal(int32(1)))
+			Ω(runner3.Signaled()).Should(Equal(int32(1)))
+			Ω(runner2.Runs()).Should(Equal(int32(2)))
+		})
+
+		Context("and earlier restarts are older than the period", func() {
+			BeforeEach(func() {
+				policy.Period = 0
+			})
+
+			It("keeps restarting", func() {
+				runner2.fail <- errors.New("boom")
+				Eventually(runner2.Runs).Should(Equal(int32(2)))
+				runner2.fail <- errors.New("boom again")
+				Eventually(runner2.Runs).Should(Equal(int32(3)))
+			})
+		})
+	})
+})
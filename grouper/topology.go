@@ -0,0 +1,104 @@
+package grouper
+
+import (
+	"sync"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+A NodeKind describes what a TopologyNode represents: one of the group kinds, or
+a plain member.
+*/
+type NodeKind string
+
+const (
+	NodeMember   NodeKind = "member"
+	NodeParallel NodeKind = "parallel"
+	NodeOrdered  NodeKind = "ordered"
+	NodeDynamic  NodeKind = "dynamic"
+)
+
+/*
+A TopologyNode describes a group, or a member of a group, in a live supervision
+tree.  Children lists the members a group has started, in the order they were
+started, or by name for a dynamic group, which only lists its running
+members.  A member whose Runner is a TreeProvider is described by its own
+tree; any other member is a leaf.
+
+The root node is unnamed, and its State is that of the group's Run.  Every
+other node is named for its member, and has the State of its process.  A
+tree is a snapshot, and may be out of date as soon as it is returned.
+*/
+type TopologyNode struct {
+	Name     string
+	Kind     NodeKind
+	State    ifrit.ProcessState
+	Children []TopologyNode
+}
+
+/*
+TreeProvider is implemented by runners which can describe their members as a
+tree, as the parallel, ordered and dynamic groups do.
+*/
+type TreeProvider interface {
+	Tree() TopologyNode
+}
+
+// memberNode describes a started member, descending into it if it is a
+// TreeProvider.
+func memberNode(member Member, process ifrit.Process) TopologyNode {
+	node := TopologyNode{Kind: NodeMember}
+	if provider, ok := member.runner().(TreeProvider); ok {
+		node = provider.Tree()
+	}
+	node.Name = member.Name
+	node.State = process.State()
+	return node
+}
+
+// topology records a group's state, and the members it has started, so that
+// a tree may be taken from another goroutine while the group runs.
+type topology struct {
+	lock      *sync.Mutex
+	state     ifrit.ProcessState
+	members   Members
+	processes []ifrit.Process
+}
+
+func newTopology() *topology {
+	return &topology{lock: new(sync.Mutex)}
+}
+
+func (t *topology) setState(state ifrit.ProcessState) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.state = state
+}
+
+// started records a member's process, replacing any previous process of the
+// same member.
+func (t *topology) started(member Member, process ifrit.Process) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i := range t.members {
+		if t.members[i].Name == member.Name {
+			t.processes[i] = process
+			return
+		}
+	}
+	t.members = append(t.members, member)
+	t.processes = append(t.processes, process)
+}
+
+func (t *topology) tree(kind NodeKind) TopologyNode {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	node := TopologyNode{Kind: kind, State: t.state}
+	for i, member := range t.members {
+		node.Children = append(node.Children, memberNode(member, t.processes[i]))
+	}
+	return node
+}
@@ -0,0 +1,116 @@
+package grouper_test
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tree", func() {
+	var (
+		leaf  func() ifrit.Runner
+		group ifrit.Runner
+	)
+
+	BeforeEach(func() {
+		leaf = func() ifrit.Runner {
+			return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				<-signals
+				return nil
+			})
+		}
+
+		inner := grouper.NewOrdered(os.Interrupt, grouper.Members{
+			{Name: "db", Runner: leaf()},
+			{Name: "api", Runner: leaf()},
+		})
+
+		group = grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "metrics", Runner: leaf()},
+			grouper.NewNestedMember("backend", inner),
+		})
+	})
+
+	It("describes a nested group as a tree", func() {
+		process := ifrit.Invoke(group)
+		defer func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		}()
+
+		tree := group.(grouper.TreeProvider).Tree()
+		Ω(tree.Kind).Should(Equal(grouper.NodeParallel))
+		Ω(tree.State).Should(Equal(ifrit.StateReady))
+		Ω(tree.Children).Should(ConsistOf(
+			grouper.TopologyNode{
+				Name:  "metrics",
+				Kind:  grouper.NodeMember,
+				State: ifrit.StateReady,
+			},
+			grouper.TopologyNode{
+				Name:  "backend",
+				Kind:  grouper.NodeOrdered,
+				State: ifrit.StateReady,
+				Children: []grouper.TopologyNode{
+					{Name: "db", Kind: grouper.NodeMember, State: ifrit.StateReady},
+					{Name: "api", Kind: grouper.NodeMember, State: ifrit.StateReady},
+				},
+			},
+		))
+	})
+
+	It("reports the exit of the group and its members", func() {
+		process := ifrit.Invoke(group)
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+
+		tree := group.(grouper.TreeProvider).Tree()
+		Ω(tree.State).Should(Equal(ifrit.StateExited))
+		for _, child := range tree.Children {
+			Ω(child.State).Should(Equal(ifrit.StateExited))
+		}
+	})
+
+	It("describes a quorum group", func() {
+		quorum := grouper.NewParallelQuorum(os.Interrupt, grouper.Members{
+			{Name: "a", Runner: leaf()},
+			{Name: "b", Runner: leaf()},
+		}, 1)
+		process := ifrit.Invoke(quorum)
+		defer func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		}()
+
+		tree := quorum.(grouper.TreeProvider).Tree()
+		Ω(tree.Kind).Should(Equal(grouper.NodeParallel))
+		Ω(tree.State).Should(Equal(ifrit.StateReady))
+		Ω(tree.Children).Should(HaveLen(2))
+	})
+
+	It("lists the running members of a dynamic group", func() {
+		pool := grouper.NewDynamic(nil, 2, 2)
+		process := ifrit.Invoke(pool)
+		defer func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		}()
+
+		Ω(pool.Client().InsertAll(grouper.Members{
+			{Name: "b", Runner: leaf()},
+			{Name: "a", Runner: leaf()},
+		})).Should(Succeed())
+		Eventually(func() int { return len(pool.Client().Select("")) }).Should(Equal(2))
+
+		tree := pool.(grouper.TreeProvider).Tree()
+		Ω(tree.Kind).Should(Equal(grouper.NodeDynamic))
+		Ω(tree.Children).Should(HaveLen(2))
+		Ω(tree.Children[0].Name).Should(Equal("a"))
+		Ω(tree.Children[1].Name).Should(Equal("b"))
+	})
+})
@@ -0,0 +1,36 @@
+package grouper
+
+/*
+A Tracer opens spans around the lifecycle of dynamic group members. StartSpan
+returns the new span, along with a function which finishes the span, recording
+the error, if any, which ended it.
+
+The dynamic group opens a "startup" span when a member is inserted, finishing
+it once the member is ready, and a "run" span when the member is ready,
+finishing it once the member exits.  Every span is tagged with the member name.
+*/
+type Tracer interface {
+	StartSpan(name string) (Span, func(err error))
+}
+
+/*
+A Span is a traced unit of work which can be tagged with metadata.
+*/
+type Span interface {
+	SetTag(key string, value string)
+}
+
+// MemberTag is the span tag holding the member name.
+const MemberTag = "member"
+
+func startMemberSpan(tracer Tracer, name string, member Member) func(error) {
+	if tracer == nil {
+		return finishNothing
+	}
+
+	span, finish := tracer.StartSpan(name)
+	span.SetTag(MemberTag, member.Name)
+	return finish
+}
+
+func finishNothing(error) {}
@@ -0,0 +1,137 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeSpan struct {
+	Name     string
+	Tags     map[string]string
+	Finished bool
+	Err      error
+
+	lock *sync.Mutex
+}
+
+func (s *fakeSpan) SetTag(key string, value string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Tags[key] = value
+}
+
+type fakeTracer struct {
+	lock  sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) StartSpan(name string) (grouper.Span, func(error)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	span := &fakeSpan{Name: name, Tags: map[string]string{}, lock: &t.lock}
+	t.spans = append(t.spans, span)
+
+	return span, func(err error) {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		span.Finished = true
+		span.Err = err
+	}
+}
+
+func (t *fakeTracer) Spans() []fakeSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	spans := make([]fakeSpan, 0, len(t.spans))
+	for _, span := range t.spans {
+		tags := map[string]string{}
+		for key, value := range span.Tags {
+			tags[key] = value
+		}
+		spans = append(spans, fakeSpan{Name: span.Name, Tags: tags, Finished: span.Finished, Err: span.Err})
+	}
+	return spans
+}
+
+var _ = Describe("Tracer", func() {
+	var (
+		tracer      *fakeTracer
+		client      grouper.DynamicClient
+		poolProcess ifrit.Process
+		runner      *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		tracer = &fakeTracer{}
+		runner = fake_runner.NewTestRunner()
+
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity:     1,
+			EventBufferSize: 1,
+			Tracer:          tracer,
+		})
+		client = pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+
+		client.Inserter() <- grouper.Member{"child1", runner}
+	})
+
+	AfterEach(func() {
+		runner.EnsureExit()
+		poolProcess.Signal(os.Kill)
+		Eventually(poolProcess.Wait()).Should(Receive())
+	})
+
+	It("opens a startup span when the member is inserted", func() {
+		Eventually(tracer.Spans).Should(HaveLen(1))
+		span := tracer.Spans()[0]
+		Ω(span.Name).Should(Equal("startup"))
+		Ω(span.Tags).Should(Equal(map[string]string{grouper.MemberTag: "child1"}))
+		Ω(span.Finished).Should(BeFalse())
+	})
+
+	It("closes the startup span and opens a run span when the member is ready", func() {
+		runner.TriggerReady()
+
+		Eventually(tracer.Spans).Should(HaveLen(2))
+		spans := tracer.Spans()
+		Ω(spans[0].Finished).Should(BeTrue())
+		Ω(spans[0].Err).ShouldNot(HaveOccurred())
+
+		Ω(spans[1].Name).Should(Equal("run"))
+		Ω(spans[1].Tags).Should(Equal(map[string]string{grouper.MemberTag: "child1"}))
+		Ω(spans[1].Finished).Should(BeFalse())
+	})
+
+	It("closes the run span with the exit error", func() {
+		runner.TriggerReady()
+		runner.TriggerExit(errors.New("boom"))
+
+		Eventually(func() bool {
+			spans := tracer.Spans()
+			return len(spans) == 2 && spans[1].Finished
+		}).Should(BeTrue())
+		Ω(tracer.Spans()[1].Err).Should(Equal(errors.New("boom")))
+	})
+
+	It("closes the startup span with the error when the member fails before becoming ready", func() {
+		runner.TriggerExit(errors.New("boom"))
+
+		Eventually(func() bool {
+			spans := tracer.Spans()
+			return len(spans) == 1 && spans[0].Finished
+		}).Should(BeTrue())
+		Consistently(tracer.Spans).Should(HaveLen(1))
+		Ω(tracer.Spans()[0].Err).Should(Equal(errors.New("boom")))
+	})
+})
@@ -0,0 +1,88 @@
+package grouper
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+const (
+	tcpWaitInitialDelay = 10 * time.Millisecond
+	tcpWaitMaxDelay     = time.Second
+)
+
+/*
+NewWaitForTCP gates a Runner on a TCP dependency.  It dials addr, backing off
+between attempts, until a connection succeeds, and then runs the inner Runner,
+becoming ready once the inner Runner is ready.  If no connection succeeds within
+the timeout, it returns ErrTCPWaitTimeout without running the inner Runner.  A
+signal received while waiting aborts the wait, and the Runner exits cleanly.
+*/
+func NewWaitForTCP(addr string, timeout time.Duration, inner ifrit.Runner) ifrit.Runner {
+	return tcpWaiter{
+		addr:    addr,
+		timeout: timeout,
+		inner:   inner,
+	}
+}
+
+type tcpWaiter struct {
+	addr    string
+	timeout time.Duration
+	inner   ifrit.Runner
+}
+
+func (w tcpWaiter) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	backoff := ifrit.ExponentialBackoff(tcpWaitInitialDelay, tcpWaitMaxDelay, 2)
+
+	for {
+		attempt := make(chan error, 1)
+		go func() {
+			var dialer net.Dialer
+			conn, err := dialer.DialContext(ctx, "tcp", w.addr)
+			if err == nil {
+				conn.Close()
+			}
+			attempt <- err
+		}()
+
+		select {
+		case <-signals:
+			return nil
+		case <-ctx.Done():
+			return ErrTCPWaitTimeout{Addr: w.addr, Timeout: w.timeout}
+		case err := <-attempt:
+			if err == nil {
+				return w.inner.Run(signals, ready)
+			}
+		}
+
+		select {
+		case <-signals:
+			return nil
+		case <-ctx.Done():
+			return ErrTCPWaitTimeout{Addr: w.addr, Timeout: w.timeout}
+		case <-time.After(backoff.Next()):
+		}
+	}
+}
+
+/*
+ErrTCPWaitTimeout is returned by a Runner created with NewWaitForTCP when it
+could not connect to Addr within Timeout.
+*/
+type ErrTCPWaitTimeout struct {
+	Addr    string
+	Timeout time.Duration
+}
+
+func (e ErrTCPWaitTimeout) Error() string {
+	return fmt.Sprintf("Timed out after %s waiting for %s", e.Timeout, e.Addr)
+}
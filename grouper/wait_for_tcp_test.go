@@ -0,0 +1,91 @@
+package grouper_test
+
+import (
+	"net"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitForTCP", func() {
+	var (
+		addr    string
+		inner   *fake_runner.TestRunner
+		process ifrit.Process
+
+		Δ time.Duration = 50 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).ShouldNot(HaveOccurred())
+		addr = listener.Addr().String()
+		listener.Close()
+
+		inner = fake_runner.NewTestRunner()
+	})
+
+	AfterEach(func() {
+		inner.EnsureExit()
+	})
+
+	Context("when the address starts accepting connections partway through", func() {
+		var listener net.Listener
+
+		BeforeEach(func() {
+			process = ifrit.Background(grouper.NewWaitForTCP(addr, 5*time.Second, inner))
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("runs the inner runner once connected, and becomes ready with it", func() {
+			Consistently(inner.RunCallCount, Δ).Should(BeZero())
+
+			var err error
+			listener, err = net.Listen("tcp", addr)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			signals := inner.WaitForCall()
+			Ω(process.Ready()).ShouldNot(BeClosed())
+
+			inner.TriggerReady()
+			Eventually(process.Ready()).Should(BeClosed())
+
+			process.Signal(os.Interrupt)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			inner.TriggerExit(nil)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when the address is unreachable", func() {
+		BeforeEach(func() {
+			process = ifrit.Background(grouper.NewWaitForTCP(addr, Δ, inner))
+		})
+
+		It("times out without running the inner runner", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(grouper.ErrTCPWaitTimeout{Addr: addr, Timeout: Δ})))
+			Ω(inner.RunCallCount()).Should(BeZero())
+		})
+	})
+
+	Context("when signaled while waiting", func() {
+		BeforeEach(func() {
+			process = ifrit.Background(grouper.NewWaitForTCP(addr, time.Minute, inner))
+		})
+
+		It("returns promptly", func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Ω(inner.RunCallCount()).Should(BeZero())
+		})
+	})
+})
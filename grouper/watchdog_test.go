@@ -0,0 +1,99 @@
+package grouper_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stuck member watchdog", func() {
+	type stuckCall struct {
+		name   string
+		waited time.Duration
+	}
+
+	var (
+		stuck       chan stuckCall
+		poolProcess ifrit.Process
+		client      grouper.DynamicClient
+		runner      *fake_runner.TestRunner
+
+		threshold time.Duration = 20 * time.Millisecond
+	)
+
+	BeforeEach(func() {
+		stuck = make(chan stuckCall, 2)
+		runner = fake_runner.NewTestRunner()
+
+		pool := grouper.NewDynamicWithConfig(grouper.DynamicConfig{
+			MaxCapacity:    1,
+			StuckThreshold: threshold,
+			OnMemberStuck: func(member grouper.Member, waited time.Duration) {
+				stuck <- stuckCall{member.Name, waited}
+			},
+		})
+		client = pool.Client()
+		poolProcess = ifrit.Invoke(pool)
+
+		client.Inserter() <- grouper.Member{"child1", runner}
+	})
+
+	AfterEach(func() {
+		runner.EnsureExit()
+		poolProcess.Signal(os.Kill)
+		Eventually(poolProcess.Wait()).Should(Receive())
+	})
+
+	It("reports a member which is slow to become ready, without stopping it", func() {
+		signals := runner.WaitForCall()
+
+		var call stuckCall
+		Eventually(stuck).Should(Receive(&call))
+		Ω(call.name).Should(Equal("child1"))
+		Ω(call.waited).Should(BeNumerically(">=", threshold))
+		Ω(signals).ShouldNot(Receive())
+
+		runner.TriggerReady()
+		entrances := client.EntranceListener()
+		Eventually(entrances).Should(Receive())
+		Consistently(stuck, 2*threshold).ShouldNot(Receive())
+	})
+
+	It("emits no entrance event for a member which neither becomes ready nor exits", func() {
+		entrances := client.EntranceListener()
+
+		Eventually(stuck).Should(Receive())
+		Consistently(entrances, 2*threshold).ShouldNot(Receive())
+
+		runner.TriggerExit(nil)
+		Eventually(entrances).Should(Receive())
+	})
+
+	It("does not report a member which becomes ready in time", func() {
+		runner.TriggerReady()
+		Consistently(stuck, 2*threshold).ShouldNot(Receive())
+	})
+
+	It("does not report a member which exits in time", func() {
+		runner.TriggerExit(nil)
+		Consistently(stuck, 2*threshold).ShouldNot(Receive())
+	})
+
+	It("emits an entrance event for a member which exits immediately, before its exit event", func() {
+		entrances := client.EntranceListener()
+		exits := client.ExitListener()
+		runner.TriggerExit(nil)
+
+		var entrance grouper.EntranceEvent
+		Eventually(entrances).Should(Receive(&entrance))
+		Ω(entrance.Member.Name).Should(Equal("child1"))
+		Ω(entrance.Process.Ready()).ShouldNot(BeClosed())
+		Eventually(exits).Should(Receive())
+	})
+})
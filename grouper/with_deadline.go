@@ -0,0 +1,52 @@
+package grouper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+WithDeadline runs the runner, typically a group, until the wall-clock deadline.
+If it is still running at the deadline, it is sent sig, and once it exits,
+WithDeadline returns ErrDeadlineExceeded, wrapping the runner's error.  A runner
+which exits before the deadline returns its own error.  Readiness and signals
+are forwarded as by ifrit.WithMaxDuration; the time remaining until the deadline
+is measured when Run is called.
+*/
+func WithDeadline(runner ifrit.Runner, deadline time.Time, sig os.Signal) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		err := ifrit.WithMaxDuration(runner, time.Until(deadline), sig).Run(signals, ready)
+
+		var exceeded ifrit.ErrMaxDurationExceeded
+		if errors.As(err, &exceeded) {
+			return ErrDeadlineExceeded{Deadline: deadline, Err: exceeded.Err}
+		}
+		return err
+	})
+}
+
+/*
+ErrDeadlineExceeded is returned by a runner wrapped with WithDeadline which had
+to be stopped at its deadline.  Err is the error the runner then exited with,
+such as the ErrorTrace of a group.
+*/
+type ErrDeadlineExceeded struct {
+	Deadline time.Time
+	Err      error
+}
+
+func (e ErrDeadlineExceeded) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("Deadline exceeded at %s", e.Deadline.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("Deadline exceeded at %s: %s", e.Deadline.Format(time.RFC3339), e.Err)
+}
+
+// Unwrap returns the runner's error.
+func (e ErrDeadlineExceeded) Unwrap() error {
+	return e.Err
+}
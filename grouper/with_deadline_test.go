@@ -0,0 +1,71 @@
+package grouper_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithDeadline", func() {
+	var (
+		childRunner *fake_runner.TestRunner
+		group       ifrit.Runner
+	)
+
+	BeforeEach(func() {
+		childRunner = fake_runner.NewTestRunner()
+		group = grouper.NewParallel(os.Interrupt, grouper.Members{
+			{Name: "child", Runner: childRunner},
+		})
+	})
+
+	AfterEach(func() {
+		childRunner.EnsureExit()
+	})
+
+	Context("when the deadline passes", func() {
+		It("shuts the group down, and returns ErrDeadlineExceeded wrapping its error", func() {
+			deadline := time.Now().Add(20 * time.Millisecond)
+			process := ifrit.Background(grouper.WithDeadline(group, deadline, os.Interrupt))
+
+			signals := childRunner.WaitForCall()
+			childRunner.TriggerReady()
+			Eventually(process.Ready()).Should(BeClosed())
+
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			childRunner.TriggerExit(errors.New("stopped"))
+
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(grouper.ErrDeadlineExceeded{}))
+			Ω(err.(grouper.ErrDeadlineExceeded).Deadline).Should(Equal(deadline))
+
+			var trace grouper.ErrorTrace
+			Ω(errors.As(err, &trace)).Should(BeTrue())
+			Ω(trace[0].Err).Should(MatchError("stopped"))
+		})
+	})
+
+	Context("when the group finishes before the deadline", func() {
+		It("returns the group's own error, and never signals it", func() {
+			deadline := time.Now().Add(50 * time.Millisecond)
+			process := ifrit.Background(grouper.WithDeadline(group, deadline, os.Interrupt))
+
+			signals := childRunner.WaitForCall()
+			childRunner.TriggerReady()
+			childRunner.TriggerExit(nil)
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			time.Sleep(time.Until(deadline))
+			Consistently(signals).ShouldNot(Receive())
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package grouper
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+/*
+NewWorkQueue runs its members as a pool of workers: at most maxConcurrent
+members run at a time, and as each exits, the next member is started, in
+order.  The group is ready once it has begun, and exits once every member has
+run and exited.  A member which exits, even with an error, does not stop the
+others.  A maxConcurrent of zero or less runs every member at once.
+
+If any member exits with an error, the group returns an ErrorTrace of every
+member's exit.  When signaled, the signal is propagated to the running members,
+and the members which have not yet started are never run.
+*/
+func NewWorkQueue(members []Member, maxConcurrent int) ifrit.Runner {
+	if maxConcurrent <= 0 || maxConcurrent > len(members) {
+		maxConcurrent = len(members)
+	}
+
+	return workQueue{
+		members:       members,
+		maxConcurrent: maxConcurrent,
+	}
+}
+
+type workQueue struct {
+	members       Members
+	maxConcurrent int
+}
+
+// GroupConfig reports the number of members the queue runs at a time.
+func (q workQueue) GroupConfig() GroupConfigInfo {
+	return GroupConfigInfo{PoolSize: q.maxConcurrent}
+}
+
+func (q workQueue) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := q.members.Validate()
+	if err != nil {
+		return err
+	}
+
+	if len(q.members) == 0 {
+		close(ready)
+		return nil
+	}
+
+	pool := NewDynamic(nil, q.maxConcurrent, len(q.members))
+	client := pool.Client()
+	exits := client.ExitListener()
+	poolProcess := ifrit.Background(pool)
+
+	err = client.InsertAll(q.members)
+	if err != nil {
+		poolProcess.Signal(os.Kill)
+		<-poolProcess.Wait()
+		return err
+	}
+	client.Close()
+
+	close(ready)
+
+	errTrace := ErrorTrace{}
+	errOccurred := false
+	for {
+		select {
+		case signal := <-signals:
+			poolProcess.Signal(signal)
+
+		case exit, ok := <-exits:
+			if !ok {
+				<-poolProcess.Wait()
+				if errOccurred {
+					return errTrace
+				}
+				return nil
+			}
+
+			// Members which were never started are not part of the trace.
+			if _, rejected := exit.Err.(ErrMemberRejected); rejected {
+				continue
+			}
+
+			errTrace = append(errTrace, exit)
+			if exit.Err != nil {
+				errOccurred = true
+			}
+		}
+	}
+}
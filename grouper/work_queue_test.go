@@ -0,0 +1,102 @@
+package grouper_test
+
+import (
+	"errors"
+	"syscall"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	"github.com/tedsuo/ifrit/grouper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkQueue", func() {
+	var (
+		groupProcess ifrit.Process
+
+		childRunner1 *fake_runner.TestRunner
+		childRunner2 *fake_runner.TestRunner
+		childRunner3 *fake_runner.TestRunner
+	)
+
+	BeforeEach(func() {
+		childRunner1 = fake_runner.NewTestRunner()
+		childRunner2 = fake_runner.NewTestRunner()
+		childRunner3 = fake_runner.NewTestRunner()
+
+		groupProcess = ifrit.Background(grouper.NewWorkQueue(grouper.Members{
+			{Name: "child1", Runner: childRunner1},
+			{Name: "child2", Runner: childRunner2},
+			{Name: "child3", Runner: childRunner3},
+		}, 2))
+	})
+
+	AfterEach(func() {
+		childRunner1.EnsureExit()
+		childRunner2.EnsureExit()
+		childRunner3.EnsureExit()
+
+		ginkgomon.Kill(groupProcess)
+	})
+
+	It("runs at most maxConcurrent members at a time, and exits once all have run", func() {
+		Eventually(groupProcess.Ready()).Should(BeClosed())
+		Eventually(childRunner1.RunCallCount).Should(Equal(1))
+		Eventually(childRunner2.RunCallCount).Should(Equal(1))
+		Consistently(childRunner3.RunCallCount).Should(BeZero())
+
+		childRunner1.TriggerExit(nil)
+		Eventually(childRunner3.RunCallCount).Should(Equal(1))
+
+		childRunner2.TriggerExit(nil)
+		Consistently(groupProcess.Wait()).ShouldNot(Receive())
+
+		childRunner3.TriggerExit(nil)
+		Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("keeps running the other members when one fails, and returns every exit", func() {
+		jobErr := errors.New("job failed")
+		childRunner2.TriggerExit(jobErr)
+		Eventually(childRunner3.RunCallCount).Should(Equal(1))
+
+		childRunner1.TriggerExit(nil)
+		childRunner3.TriggerExit(nil)
+
+		var err error
+		Eventually(groupProcess.Wait()).Should(Receive(&err))
+		Ω(err).Should(BeAssignableToTypeOf(grouper.ErrorTrace{}))
+
+		errTrace := err.(grouper.ErrorTrace)
+		Ω(errTrace).Should(HaveLen(3))
+		Ω(errTrace[0].Member.Name).Should(Equal("child2"))
+		Ω(errors.Is(errTrace[0].Err, jobErr)).Should(BeTrue())
+	})
+
+	It("signals the running members, and never starts the rest, when signaled", func() {
+		signal1 := childRunner1.WaitForCall()
+		signal2 := childRunner2.WaitForCall()
+
+		groupProcess.Signal(syscall.SIGUSR2)
+		Eventually(signal1).Should(Receive(Equal(syscall.SIGUSR2)))
+		Eventually(signal2).Should(Receive(Equal(syscall.SIGUSR2)))
+
+		childRunner1.TriggerExit(nil)
+		childRunner2.TriggerExit(nil)
+
+		Eventually(groupProcess.Wait()).Should(Receive(BeNil()))
+		Ω(childRunner3.RunCallCount()).Should(BeZero())
+	})
+
+})
+
+var _ = Describe("WorkQueue without members", func() {
+	It("exits immediately", func() {
+		process := ifrit.Background(grouper.NewWorkQueue(nil, 2))
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Ω(process.Ready()).Should(BeClosed())
+	})
+})
@@ -0,0 +1,70 @@
+package ifrit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// DefaultHTTPShutdownTimeout is the ShutdownTimeout used by NewHTTPServer.
+const DefaultHTTPShutdownTimeout = 10 * time.Second
+
+/*
+NewHTTPServer serves handler on addr.  The listener is bound before the Runner
+becomes ready, so a port which is already in use fails startup, rather than
+being discovered after the Runner is ready.  On a signal, the server is shut
+down gracefully, waiting up to DefaultHTTPShutdownTimeout for active requests
+to finish; use HTTPServer directly to configure this.
+*/
+func NewHTTPServer(addr string, handler http.Handler) Runner {
+	return HTTPServer{
+		Addr:            addr,
+		Handler:         handler,
+		ShutdownTimeout: DefaultHTTPShutdownTimeout,
+	}
+}
+
+/*
+HTTPServer implements NewHTTPServer.  Run returns the error from binding the
+listener, or from serving, and once signaled, the error from
+http.Server.Shutdown, which is the context's error if active requests did not
+finish within the ShutdownTimeout.  A ShutdownTimeout of zero waits
+indefinitely.
+*/
+type HTTPServer struct {
+	Addr            string
+	Handler         http.Handler
+	ShutdownTimeout time.Duration
+}
+
+func (s HTTPServer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: s.Handler}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	close(ready)
+
+	select {
+	case err := <-serveErr:
+		return err
+
+	case <-signals:
+		ctx := context.Background()
+		if s.ShutdownTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.ShutdownTimeout)
+			defer cancel()
+		}
+		return server.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,70 @@
+package ifrit_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("NewHTTPServer", func() {
+	var (
+		addr    string
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).ShouldNot(HaveOccurred())
+		addr = listener.Addr().String()
+		Ω(listener.Close()).Should(Succeed())
+
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "hello")
+		})
+	})
+
+	It("serves once ready, and shuts down cleanly when signaled", func() {
+		process := ifrit.Invoke(ifrit.NewHTTPServer(addr, handler))
+		Ω(process.Ready()).Should(BeClosed())
+
+		resp, err := http.Get("http://" + addr)
+		Ω(err).ShouldNot(HaveOccurred())
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(body)).Should(Equal("hello"))
+
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		_, err = http.Get("http://" + addr)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	Context("when the address is already in use", func() {
+		var listener net.Listener
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", addr)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("fails without becoming ready", func() {
+			process := ifrit.Background(ifrit.NewHTTPServer(addr, handler))
+
+			Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+			Ω(process.Ready()).ShouldNot(BeClosed())
+		})
+	})
+})
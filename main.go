@@ -0,0 +1,38 @@
+package ifrit
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+/*
+Main runs the runner in the background and blocks until it exits, returning
+its error.  The first of the given OS signals to arrive is forwarded to the process; if no
+signals are given, os.Interrupt and syscall.SIGTERM are used.
+
+Signal handling is restored as soon as the first signal has been forwarded, so
+a second signal takes its default action, typically terminating a process
+which is slow to shut down.  It is also restored when Main returns.
+*/
+func Main(runner Runner, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	osSignals := make(chan os.Signal, 1)
+	signal.Notify(osSignals, signals...)
+	defer signal.Stop(osSignals)
+
+	process := Background(runner)
+
+	select {
+	case sig := <-osSignals:
+		signal.Stop(osSignals)
+		process.Signal(sig)
+	case err := <-process.Wait():
+		return err
+	}
+
+	return <-process.Wait()
+}
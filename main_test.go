@@ -0,0 +1,56 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Main", func() {
+	var (
+		started  chan struct{}
+		received chan os.Signal
+		runner   ifrit.Runner
+	)
+
+	BeforeEach(func() {
+		started = make(chan struct{})
+		received = make(chan os.Signal, 1)
+
+		runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			close(started)
+			received <- <-signals
+			return errors.New("shut down")
+		})
+	})
+
+	It("forwards the first OS signal and returns the runner's error", func() {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- ifrit.Main(runner, syscall.SIGUSR1)
+		}()
+
+		Eventually(started).Should(BeClosed())
+		Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).Should(Succeed())
+
+		Eventually(received).Should(Receive(Equal(syscall.SIGUSR1)))
+		Eventually(errs).Should(Receive(MatchError("shut down")))
+	})
+
+	Context("when the runner exits on its own", func() {
+		BeforeEach(func() {
+			runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				return errors.New("failed")
+			})
+		})
+
+		It("returns the runner's error", func() {
+			Ω(ifrit.Main(runner, syscall.SIGUSR1)).Should(MatchError("failed"))
+		})
+	})
+})
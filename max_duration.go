@@ -0,0 +1,71 @@
+package ifrit
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+/*
+WithMaxDuration runs the inner Runner for at most d.  If it has not exited
+within d, it is sent sig, and once it exits, WithMaxDuration returns
+ErrMaxDurationExceeded rather than its error.  Readiness is forwarded from the
+inner Runner, as are signals; once the inner Runner has been signaled, the
+deadline no longer applies.
+*/
+func WithMaxDuration(inner Runner, d time.Duration, sig os.Signal) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		process := Background(inner)
+		processReady := process.Ready()
+		exit := process.Wait()
+
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		deadline := timer.C
+		exceeded := false
+
+		for {
+			select {
+			case <-processReady:
+				processReady = nil
+				close(ready)
+
+			case signal := <-signals:
+				deadline = nil
+				process.Signal(signal)
+
+			case <-deadline:
+				deadline = nil
+				exceeded = true
+				process.Signal(sig)
+
+			case err := <-exit:
+				if exceeded {
+					return ErrMaxDurationExceeded{Duration: d, Err: err}
+				}
+				return err
+			}
+		}
+	})
+}
+
+/*
+ErrMaxDurationExceeded is returned by a Runner wrapped with WithMaxDuration
+which had to be stopped at its deadline.  Err is the error it then exited with.
+*/
+type ErrMaxDurationExceeded struct {
+	Duration time.Duration
+	Err      error
+}
+
+func (e ErrMaxDurationExceeded) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("Runner exceeded its maximum duration of %s", e.Duration)
+	}
+	return fmt.Sprintf("Runner exceeded its maximum duration of %s: %s", e.Duration, e.Err)
+}
+
+// Unwrap returns the error the Runner exited with.
+func (e ErrMaxDurationExceeded) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,73 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("WithMaxDuration", func() {
+	var (
+		inner   *fake_runner.TestRunner
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		inner = fake_runner.NewTestRunner()
+	})
+
+	AfterEach(func() {
+		inner.EnsureExit()
+	})
+
+	Context("when the inner runner finishes in time", func() {
+		BeforeEach(func() {
+			process = ifrit.Background(ifrit.WithMaxDuration(inner, time.Hour, syscall.SIGUSR1))
+		})
+
+		It("forwards readiness and returns the inner runner's error", func() {
+			inner.TriggerReady()
+			Eventually(process.Ready()).Should(BeClosed())
+
+			inner.TriggerExit(errors.New("done"))
+			Eventually(process.Wait()).Should(Receive(MatchError("done")))
+		})
+
+		It("forwards signals", func() {
+			signals := inner.WaitForCall()
+			process.Signal(os.Interrupt)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+			inner.TriggerExit(nil)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when the inner runner overruns", func() {
+		BeforeEach(func() {
+			process = ifrit.Background(ifrit.WithMaxDuration(inner, 10*time.Millisecond, syscall.SIGUSR1))
+		})
+
+		It("signals it at the deadline, and returns ErrMaxDurationExceeded once it exits", func() {
+			signals := inner.WaitForCall()
+			inner.TriggerReady()
+
+			Eventually(signals).Should(Receive(Equal(syscall.SIGUSR1)))
+			Consistently(process.Wait()).ShouldNot(Receive())
+
+			inner.TriggerExit(errors.New("stopped"))
+
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(ifrit.ErrMaxDurationExceeded{}))
+			Ω(err.(ifrit.ErrMaxDurationExceeded).Duration).Should(Equal(10 * time.Millisecond))
+			Ω(errors.Unwrap(err)).Should(MatchError("stopped"))
+		})
+	})
+})
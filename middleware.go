@@ -0,0 +1,76 @@
+package ifrit
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+)
+
+/*
+A RunnerMiddleware wraps a Runner with additional behavior.  The Runner it
+returns must forward signals to, and close ready when, the wrapped Runner does.
+*/
+type RunnerMiddleware func(Runner) Runner
+
+/*
+Chain wraps a Runner with each of the middlewares.  The first middleware is the
+outermost, so Chain(r, a, b) is equivalent to a(b(r)).
+*/
+func Chain(r Runner, middlewares ...RunnerMiddleware) Runner {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		r = middlewares[i](r)
+	}
+	return r
+}
+
+/*
+PanicError is returned in place of a Runner which panicked.  Value is the value
+passed to panic, and Stack is the stack trace of the panicking goroutine.
+*/
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("Runner panicked: %v", e.Value)
+}
+
+/*
+RecoverPanics returns a middleware which recovers a panic in the wrapped Runner,
+and returns it as a PanicError.  If logf is not nil, the panic and its stack
+trace are logged with it.
+*/
+func RecoverPanics(logf func(format string, args ...interface{})) RunnerMiddleware {
+	return func(inner Runner) Runner {
+		return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) (err error) {
+			defer func() {
+				if value := recover(); value != nil {
+					panicErr := PanicError{Value: value, Stack: debug.Stack()}
+					if logf != nil {
+						logf("%s\n%s", panicErr, panicErr.Stack)
+					}
+					err = panicErr
+				}
+			}()
+
+			return inner.Run(signals, ready)
+		})
+	}
+}
+
+/*
+WithTiming returns a middleware which calls hook with how long the wrapped
+Runner ran, and the error it returned.
+*/
+func WithTiming(hook func(elapsed time.Duration, err error)) RunnerMiddleware {
+	return func(inner Runner) Runner {
+		return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			started := time.Now()
+			err := inner.Run(signals, ready)
+			hook(time.Since(started), err)
+			return err
+		})
+	}
+}
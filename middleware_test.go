@@ -0,0 +1,91 @@
+package ifrit_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("Chain", func() {
+	var calls []string
+
+	recordingMiddleware := func(name string) ifrit.RunnerMiddleware {
+		return func(inner ifrit.Runner) ifrit.Runner {
+			return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				calls = append(calls, name)
+				return inner.Run(signals, ready)
+			})
+		}
+	}
+
+	BeforeEach(func() {
+		calls = nil
+	})
+
+	It("applies the middlewares from the outermost in", func() {
+		inner := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			calls = append(calls, "inner")
+			return nil
+		})
+
+		runner := ifrit.Chain(inner, recordingMiddleware("first"), recordingMiddleware("second"))
+		Ω(runner.Run(nil, make(chan struct{}))).Should(Succeed())
+		Ω(calls).Should(Equal([]string{"first", "second", "inner"}))
+	})
+
+	It("forwards ready and signals through the chain", func() {
+		inner := fake_runner.NewTestRunner()
+		defer inner.EnsureExit()
+
+		var elapsed time.Duration
+		var timedErr error
+		proc := ifrit.Background(ifrit.Chain(inner,
+			ifrit.RecoverPanics(nil),
+			ifrit.WithTiming(func(d time.Duration, err error) {
+				elapsed = d
+				timedErr = err
+			}),
+		))
+
+		signals := inner.WaitForCall()
+		inner.TriggerReady()
+		Eventually(proc.Ready()).Should(BeClosed())
+
+		proc.Signal(os.Interrupt)
+		Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+		time.Sleep(5 * time.Millisecond)
+		inner.TriggerExit(errors.New("stopped"))
+		Eventually(proc.Wait()).Should(Receive(Equal(errors.New("stopped"))))
+		Ω(elapsed).Should(BeNumerically(">=", 5*time.Millisecond))
+		Ω(timedErr).Should(Equal(errors.New("stopped")))
+	})
+
+	Describe("RecoverPanics", func() {
+		It("converts a panic into a PanicError", func() {
+			var logged string
+			logf := func(format string, args ...interface{}) {
+				logged = fmt.Sprintf(format, args...)
+			}
+
+			panicking := ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				panic("boom")
+			})
+
+			proc := ifrit.Background(ifrit.Chain(panicking, ifrit.RecoverPanics(logf)))
+
+			var err error
+			Eventually(proc.Wait()).Should(Receive(&err))
+			Ω(err).Should(BeAssignableToTypeOf(ifrit.PanicError{}))
+			Ω(err.(ifrit.PanicError).Value).Should(Equal("boom"))
+			Ω(err.(ifrit.PanicError).Stack).ShouldNot(BeEmpty())
+			Ω(logged).Should(ContainSubstring("Runner panicked: boom"))
+		})
+	})
+})
@@ -0,0 +1,25 @@
+package ifrit
+
+import "fmt"
+
+/*
+NamedProcess wraps a Process with a name, for logging.  The returned Process
+forwards every method to p, and also has a Name() string method, and a String
+method which reports the name and current state, such as "web (ready)".
+*/
+func NamedProcess(name string, p Process) Process {
+	return namedProcess{Process: p, name: name}
+}
+
+type namedProcess struct {
+	Process
+	name string
+}
+
+func (p namedProcess) Name() string {
+	return p.name
+}
+
+func (p namedProcess) String() string {
+	return fmt.Sprintf("%s (%s)", p.name, p.State())
+}
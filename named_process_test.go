@@ -0,0 +1,67 @@
+package ifrit_test
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("NamedProcess", func() {
+	var (
+		signals chan os.Signal
+		ready   chan struct{}
+		named   ifrit.Process
+	)
+
+	BeforeEach(func() {
+		signals = make(chan os.Signal, 1)
+		ready = make(chan struct{})
+
+		named = ifrit.NamedProcess("web", ifrit.Background(ifrit.RunFunc(func(s <-chan os.Signal, r chan<- struct{}) error {
+			select {
+			case <-ready:
+				close(r)
+			case signal := <-s:
+				signals <- signal
+				return nil
+			}
+			signals <- <-s
+			return nil
+		})))
+	})
+
+	AfterEach(func() {
+		named.Signal(os.Kill)
+		Eventually(named.Wait()).Should(Receive())
+	})
+
+	It("has a name", func() {
+		Ω(named.(interface{ Name() string }).Name()).Should(Equal("web"))
+	})
+
+	It("forwards Ready, Signal and Wait to the process", func() {
+		Consistently(named.Ready()).ShouldNot(BeClosed())
+		close(ready)
+		Eventually(named.Ready()).Should(BeClosed())
+
+		named.Signal(syscall.SIGUSR2)
+		Eventually(signals).Should(Receive(Equal(syscall.SIGUSR2)))
+		Eventually(named.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("reports the name and current state when formatted", func() {
+		Ω(fmt.Sprint(named)).Should(Equal("web (starting)"))
+
+		close(ready)
+		Eventually(named.Ready()).Should(BeClosed())
+		Ω(fmt.Sprint(named)).Should(Equal("web (ready)"))
+
+		named.Signal(os.Interrupt)
+		Eventually(named.Exited()).Should(BeClosed())
+		Ω(fmt.Sprint(named)).Should(Equal("web (exited)"))
+	})
+})
@@ -0,0 +1,29 @@
+package ifrit
+
+import "os"
+
+/*
+NewOneShot returns a Runner which calls setup, becomes ready, and then holds
+until it is signaled, at which point it calls teardown and exits cleanly.  Use
+it to acquire a resource for the lifetime of a process.
+
+If setup returns an error, Run returns it without becoming ready, and teardown
+is not called.  Teardown is called exactly once per run, however many signals
+arrive; a nil teardown is skipped.
+*/
+func NewOneShot(setup func() error, teardown func()) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		err := setup()
+		if err != nil {
+			return err
+		}
+
+		close(ready)
+		<-signals
+
+		if teardown != nil {
+			teardown()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("NewOneShot", func() {
+	var (
+		setupErr  error
+		teardowns int32
+		runner    ifrit.Runner
+	)
+
+	BeforeEach(func() {
+		setupErr = nil
+		atomic.StoreInt32(&teardowns, 0)
+
+		runner = ifrit.NewOneShot(func() error {
+			return setupErr
+		}, func() {
+			atomic.AddInt32(&teardowns, 1)
+		})
+	})
+
+	teardownCount := func() int32 {
+		return atomic.LoadInt32(&teardowns)
+	}
+
+	Context("when setup fails", func() {
+		BeforeEach(func() {
+			setupErr = errors.New("boom")
+		})
+
+		It("returns the error without becoming ready or tearing down", func() {
+			process := ifrit.Background(runner)
+
+			Eventually(process.Wait()).Should(Receive(Equal(setupErr)))
+			Ω(process.Ready()).ShouldNot(BeClosed())
+			Ω(teardownCount()).Should(BeZero())
+		})
+	})
+
+	Context("when setup succeeds", func() {
+		It("holds until signaled, then tears down and exits cleanly", func() {
+			process := ifrit.Invoke(runner)
+			Consistently(process.Wait()).ShouldNot(Receive())
+			Ω(teardownCount()).Should(BeZero())
+
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Ω(teardownCount()).Should(Equal(int32(1)))
+		})
+
+		It("tears down exactly once, however many signals arrive", func() {
+			process := ifrit.Invoke(runner)
+
+			process.Signal(os.Interrupt)
+			process.Signal(os.Interrupt)
+			process.Signal(os.Kill)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Consistently(teardownCount).Should(Equal(int32(1)))
+		})
+	})
+})
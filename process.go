@@ -7,7 +7,9 @@ A Process represents a Runner that has been started.  It is safe to call any
 method on a Process even after the Process has exited.
 */
 type Process interface {
-	// Ready returns a channel which will close once the runner is active
+	// Ready returns a channel which will close once the runner is active.  It
+	// returns the same channel on every call, and as it is closed rather than
+	// sent a value, any number of goroutines may wait on it, at any time.
 	Ready() <-chan struct{}
 
 	// Wait returns a channel that will emit a single error once the Process exits.
@@ -15,6 +17,41 @@ type Process interface {
 
 	// Signal sends a shutdown signal to the Process.  It does not block.
 	Signal(os.Signal)
+
+	// State reports whether the Process is starting, ready, or exited.  It does not block.
+	State() ProcessState
+
+	// Exited returns a channel which will close once the Process exits.
+	Exited() <-chan struct{}
+}
+
+/*
+ProcessState describes the lifecycle of a Process, as reported by State.
+*/
+type ProcessState int
+
+const (
+	// StateStarting indicates the Process has neither become ready nor exited.
+	StateStarting ProcessState = iota
+
+	// StateReady indicates the Process is ready, and has not exited.
+	StateReady
+
+	// StateExited indicates the Process has exited.
+	StateExited
+)
+
+func (s ProcessState) String() string {
+	switch s {
+	case StateStarting:
+		return "starting"
+	case StateReady:
+		return "ready"
+	case StateExited:
+		return "exited"
+	default:
+		return "unknown"
+	}
 }
 
 /*
@@ -95,3 +132,22 @@ func (p *process) Signal(signal os.Signal) {
 		}
 	}()
 }
+
+func (p *process) State() ProcessState {
+	select {
+	case <-p.exited:
+		return StateExited
+	default:
+	}
+
+	select {
+	case <-p.ready:
+		return StateReady
+	default:
+		return StateStarting
+	}
+}
+
+func (p *process) Exited() <-chan struct{} {
+	return p.exited
+}
@@ -0,0 +1,72 @@
+package ifrit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+NewProcessGroup adapts processes which are already running, and are not managed
+by a group, into a Runner which stops them together.  It becomes ready at once,
+and forwards every signal it receives to each of the processes.  Run returns
+once every process has exited, whether signaled or not, with a
+ProcessGroupError of the errors they exited with, or nil if every process
+exited cleanly.
+*/
+func NewProcessGroup(procs ...Process) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+
+		type exit struct {
+			index int
+			err   error
+		}
+		exits := make(chan exit, len(procs))
+		for i, p := range procs {
+			go func(i int, p Process) {
+				exits <- exit{index: i, err: <-p.Wait()}
+			}(i, p)
+		}
+
+		errs := make([]error, len(procs))
+		for numExited := 0; numExited < len(procs); {
+			select {
+			case signal := <-signals:
+				for _, p := range procs {
+					p.Signal(signal)
+				}
+
+			case exit := <-exits:
+				errs[exit.index] = exit.err
+				numExited++
+			}
+		}
+
+		var groupErr ProcessGroupError
+		for _, err := range errs {
+			if err != nil {
+				groupErr = append(groupErr, err)
+			}
+		}
+		if groupErr != nil {
+			return groupErr
+		}
+		return nil
+	})
+}
+
+/*
+ProcessGroupError holds the errors the processes of a process group exited
+with, in the order the processes were given.  Processes which exited cleanly
+are not included.
+*/
+type ProcessGroupError []error
+
+func (e ProcessGroupError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d processes exited with errors: %s", len(e), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,72 @@
+package ifrit_test
+
+import (
+	"errors"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("NewProcessGroup", func() {
+	var (
+		runner1 *fake_runner.TestRunner
+		runner2 *fake_runner.TestRunner
+		runner3 *fake_runner.TestRunner
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		runner1 = fake_runner.NewTestRunner()
+		runner2 = fake_runner.NewTestRunner()
+		runner3 = fake_runner.NewTestRunner()
+		process = ifrit.Invoke(ifrit.NewProcessGroup(
+			ifrit.Background(runner1),
+			ifrit.Background(runner2),
+			ifrit.Background(runner3),
+		))
+	})
+
+	AfterEach(func() {
+		runner1.EnsureExit()
+		runner2.EnsureExit()
+		runner3.EnsureExit()
+		Eventually(process.Exited()).Should(BeClosed())
+	})
+
+	It("becomes ready at once", func() {
+		Ω(process.Ready()).Should(BeClosed())
+	})
+
+	It("forwards a signal to every process, and waits for them all to exit", func() {
+		signals1 := runner1.WaitForCall()
+		signals2 := runner2.WaitForCall()
+		signals3 := runner3.WaitForCall()
+
+		process.Signal(syscall.SIGUSR2)
+		Eventually(signals1).Should(Receive(Equal(syscall.SIGUSR2)))
+		Eventually(signals2).Should(Receive(Equal(syscall.SIGUSR2)))
+		Eventually(signals3).Should(Receive(Equal(syscall.SIGUSR2)))
+
+		runner1.TriggerExit(nil)
+		runner2.TriggerExit(nil)
+		Consistently(process.Wait()).ShouldNot(Receive())
+
+		runner3.TriggerExit(nil)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("returns the errors the processes exited with, in order", func() {
+		process.Signal(syscall.SIGUSR2)
+
+		runner3.TriggerExit(errors.New("three"))
+		runner2.TriggerExit(nil)
+		runner1.TriggerExit(errors.New("one"))
+
+		var err error
+		Eventually(process.Wait()).Should(Receive(&err))
+		Ω(err).Should(Equal(ifrit.ProcessGroupError{errors.New("one"), errors.New("three")}))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
 	"github.com/tedsuo/ifrit/test_helpers"
 )
 
@@ -58,6 +59,31 @@ var _ = Describe("Process", func() {
 		})
 	})
 
+	Describe("Ready()", func() {
+		It("unblocks every goroutine awaiting readiness", func() {
+			runner := fake_runner.NewTestRunner()
+			proc := ifrit.Background(runner)
+			defer runner.EnsureExit()
+
+			awaiters := 5
+			readied := make(chan struct{}, awaiters)
+			for i := 0; i < awaiters; i++ {
+				go func() {
+					<-proc.Ready()
+					readied <- struct{}{}
+				}()
+			}
+
+			Consistently(readied).ShouldNot(Receive())
+			runner.TriggerReady()
+
+			for i := 0; i < awaiters; i++ {
+				Eventually(readied).Should(Receive())
+			}
+			Ω(proc.Ready()).Should(BeClosed())
+		})
+	})
+
 	Context("when a process exits without closing ready", func() {
 		var proc ifrit.Process
 
@@ -70,4 +96,42 @@ var _ = Describe("Process", func() {
 			Ω(<-proc.Wait()).Should(Equal(test_helpers.NoReadyExitedNormally))
 		})
 	})
+
+	Describe("State()", func() {
+		var runner *fake_runner.TestRunner
+		var proc ifrit.Process
+
+		BeforeEach(func() {
+			runner = fake_runner.NewTestRunner()
+			proc = ifrit.Background(runner)
+		})
+
+		AfterEach(func() {
+			runner.EnsureExit()
+		})
+
+		It("reports the process moving from starting, to ready, to exited", func() {
+			Ω(proc.State()).Should(Equal(ifrit.StateStarting))
+			Ω(proc.Exited()).ShouldNot(BeClosed())
+
+			runner.TriggerReady()
+			Eventually(proc.State).Should(Equal(ifrit.StateReady))
+			Ω(proc.Exited()).ShouldNot(BeClosed())
+
+			runner.TriggerExit(nil)
+			Eventually(proc.Exited()).Should(BeClosed())
+			Ω(proc.State()).Should(Equal(ifrit.StateExited))
+		})
+
+		It("reports a process which exits without becoming ready as exited", func() {
+			runner.TriggerExit(nil)
+			Eventually(proc.State).Should(Equal(ifrit.StateExited))
+		})
+
+		It("has a readable String representation", func() {
+			Ω(ifrit.StateStarting.String()).Should(Equal("starting"))
+			Ω(ifrit.StateReady.String()).Should(Equal("ready"))
+			Ω(ifrit.StateExited.String()).Should(Equal("exited"))
+		})
+	})
 })
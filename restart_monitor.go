@@ -0,0 +1,118 @@
+package ifrit
+
+import (
+	"os"
+	"time"
+)
+
+// DefaultHealthyDuration is the Healthy duration used by NewRestartMonitor.
+const DefaultHealthyDuration = 10 * time.Second
+
+/*
+NewRestartMonitor runs the Runner returned by runnerFactory, and each time it
+exits, cleanly or not, waits for the backoff and runs a fresh Runner from the
+factory.  The backoff is reset whenever a Runner stays up for at least
+DefaultHealthyDuration; use RestartMonitor directly to configure this.
+*/
+func NewRestartMonitor(runnerFactory func() Runner, backoff Backoff) Runner {
+	return RestartMonitor{
+		Factory: runnerFactory,
+		Backoff: backoff,
+		Healthy: DefaultHealthyDuration,
+		After:   time.After,
+	}
+}
+
+/*
+RestartMonitor implements NewRestartMonitor.  It becomes ready once the first
+Runner is ready.  When signaled, the signal is forwarded to the running Runner,
+and the monitor returns that Runner's error once it exits; if no Runner is
+running, it returns the last Runner's error immediately.  After is used to wait
+out the backoff, and can be replaced to control the passage of time in tests.
+
+If a Runner's error carries one of the StopExitCodes, as reported by ExitCode,
+the monitor returns that error rather than restarting.  Use it for exit codes
+which restarting can not fix, such as a configuration error.  If
+StopOnCleanExit is set, the monitor also returns once a Runner exits without
+an error, so that only failures are restarted.
+*/
+type RestartMonitor struct {
+	Factory         func() Runner
+	Backoff         Backoff
+	Healthy         time.Duration
+	After           func(time.Duration) <-chan time.Time
+	StopExitCodes   []int
+	StopOnCleanExit bool
+}
+
+func (m RestartMonitor) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	after := m.After
+	if after == nil {
+		after = time.After
+	}
+
+	process := Background(m.Factory())
+	started := time.Now()
+	processReady := process.Ready()
+	exit := process.Wait()
+	signaled := false
+
+	var lastErr error
+	var restart <-chan time.Time
+
+	for {
+		select {
+		case signal := <-signals:
+			if exit == nil {
+				return lastErr
+			}
+			process.Signal(signal)
+			signaled = true
+
+		case <-processReady:
+			processReady = nil
+			if ready != nil {
+				close(ready)
+				ready = nil
+			}
+
+		case lastErr = <-exit:
+			exit = nil
+			processReady = nil
+			if signaled || m.stops(lastErr) {
+				return lastErr
+			}
+
+			if time.Since(started) >= m.Healthy {
+				m.Backoff.Reset()
+			}
+			restart = after(m.Backoff.Next())
+
+		case <-restart:
+			restart = nil
+			process = Background(m.Factory())
+			started = time.Now()
+			exit = process.Wait()
+			if ready != nil {
+				processReady = process.Ready()
+			}
+		}
+	}
+}
+
+func (m RestartMonitor) stops(err error) bool {
+	if err == nil {
+		return m.StopOnCleanExit
+	}
+
+	code, ok := ExitCode(err)
+	if !ok {
+		return false
+	}
+	for _, stopCode := range m.StopExitCodes {
+		if code == stopCode {
+			return true
+		}
+	}
+	return false
+}
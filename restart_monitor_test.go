@@ -0,0 +1,207 @@
+package ifrit_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+type doublingBackoff struct {
+	mutex  sync.Mutex
+	next   time.Duration
+	resets int
+}
+
+func (b *doublingBackoff) Next() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	next := b.next
+	b.next *= 2
+	return next
+}
+
+func (b *doublingBackoff) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.next = time.Millisecond
+	b.resets++
+}
+
+func (b *doublingBackoff) Resets() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.resets
+}
+
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (e exitCodeError) ExitCode() int {
+	return int(e)
+}
+
+var _ = Describe("RestartMonitor", func() {
+	var (
+		runners chan *fake_runner.TestRunner
+		created []*fake_runner.TestRunner
+		mutex   sync.Mutex
+		backoff *doublingBackoff
+		delays  chan time.Duration
+		monitor ifrit.RestartMonitor
+		proc    ifrit.Process
+	)
+
+	BeforeEach(func() {
+		runners = make(chan *fake_runner.TestRunner, 10)
+		created = nil
+		backoff = &doublingBackoff{next: time.Millisecond}
+		delays = make(chan time.Duration, 10)
+
+		monitor = ifrit.NewRestartMonitor(func() ifrit.Runner {
+			runner := fake_runner.NewTestRunner()
+			mutex.Lock()
+			created = append(created, runner)
+			mutex.Unlock()
+			runners <- runner
+			return runner
+		}, backoff).(ifrit.RestartMonitor)
+		monitor.Healthy = time.Hour
+		monitor.After = func(d time.Duration) <-chan time.Time {
+			delays <- d
+			return time.After(0)
+		}
+	})
+
+	JustBeforeEach(func() {
+		proc = ifrit.Background(monitor)
+	})
+
+	AfterEach(func() {
+		proc.Signal(os.Kill)
+		Eventually(func() ifrit.ProcessState {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, runner := range created {
+				runner.EnsureExit()
+			}
+			return proc.State()
+		}).Should(Equal(ifrit.StateExited))
+	})
+
+	nextRunner := func() *fake_runner.TestRunner {
+		var runner *fake_runner.TestRunner
+		Eventually(runners).Should(Receive(&runner))
+		return runner
+	}
+
+	It("becomes ready once the first runner is ready", func() {
+		runner := nextRunner()
+		Consistently(proc.Ready()).ShouldNot(BeClosed())
+
+		runner.TriggerReady()
+		Eventually(proc.Ready()).Should(BeClosed())
+	})
+
+	It("grows the backoff across rapid failures", func() {
+		for i := 0; i < 3; i++ {
+			nextRunner().TriggerExit(errors.New("boom"))
+		}
+
+		Eventually(delays).Should(Receive(Equal(1 * time.Millisecond)))
+		Eventually(delays).Should(Receive(Equal(2 * time.Millisecond)))
+		Eventually(delays).Should(Receive(Equal(4 * time.Millisecond)))
+		Ω(backoff.Resets()).Should(BeZero())
+	})
+
+	It("restarts runners which exit cleanly", func() {
+		nextRunner().TriggerExit(nil)
+		Eventually(runners).Should(Receive())
+	})
+
+	Context("when a runner stays up for the healthy duration", func() {
+		BeforeEach(func() {
+			monitor.Healthy = 0
+		})
+
+		It("resets the backoff", func() {
+			nextRunner().TriggerExit(errors.New("boom"))
+			Eventually(delays).Should(Receive(Equal(time.Millisecond)))
+			nextRunner().TriggerExit(errors.New("boom"))
+			Eventually(delays).Should(Receive(Equal(time.Millisecond)))
+			Ω(backoff.Resets()).Should(Equal(2))
+		})
+	})
+
+	Context("with StopOnCleanExit", func() {
+		BeforeEach(func() {
+			monitor.StopOnCleanExit = true
+		})
+
+		It("restarts runners which fail, and returns once one exits cleanly", func() {
+			nextRunner().TriggerExit(errors.New("boom"))
+			Eventually(delays).Should(Receive())
+
+			nextRunner().TriggerExit(nil)
+			Eventually(proc.Wait()).Should(Receive(BeNil()))
+			Consistently(runners).ShouldNot(Receive())
+		})
+	})
+
+	Context("when a runner exits with one of the StopExitCodes", func() {
+		BeforeEach(func() {
+			monitor.StopExitCodes = []int{2}
+		})
+
+		It("returns the error rather than restarting", func() {
+			nextRunner().TriggerExit(exitCodeError(3))
+			Eventually(delays).Should(Receive())
+
+			nextRunner().TriggerExit(exitCodeError(2))
+			Eventually(proc.Wait()).Should(Receive(Equal(exitCodeError(2))))
+			Consistently(runners).ShouldNot(Receive())
+		})
+	})
+
+	Context("when signaled", func() {
+		It("forwards the signal and returns the runner's error", func() {
+			runner := nextRunner()
+			runner.TriggerReady()
+			signals := runner.WaitForCall()
+
+			proc.Signal(os.Interrupt)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			runner.TriggerExit(errors.New("interrupted"))
+
+			Eventually(proc.Wait()).Should(Receive(Equal(errors.New("interrupted"))))
+			Consistently(runners).ShouldNot(Receive())
+		})
+
+		Context("while waiting to restart", func() {
+			BeforeEach(func() {
+				monitor.After = func(d time.Duration) <-chan time.Time {
+					delays <- d
+					return nil
+				}
+			})
+
+			It("returns the last error promptly", func() {
+				nextRunner().TriggerExit(errors.New("boom"))
+				Eventually(delays).Should(Receive())
+
+				proc.Signal(os.Interrupt)
+				Eventually(proc.Wait()).Should(Receive(Equal(errors.New("boom"))))
+				Ω(runners).ShouldNot(Receive())
+			})
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package ifrit
+
+import (
+	"os"
+	"time"
+)
+
+/*
+NewRetry runs the Runner returned by factory, and if it exits with an error
+before becoming ready, waits for the backoff and runs a fresh Runner from the
+factory, for at most maxAttempts attempts in all.  Use it to start a process
+whose dependencies may not yet be available.
+*/
+func NewRetry(factory func() Runner, maxAttempts int, backoff Backoff) Runner {
+	return Retry{
+		Factory:     factory,
+		MaxAttempts: maxAttempts,
+		Backoff:     backoff,
+		After:       time.After,
+	}
+}
+
+/*
+Retry implements NewRetry.  Once a Runner becomes ready, Retry becomes ready,
+and stops retrying: it forwards signals to that Runner, and returns its error
+once it exits.  A Runner which exits cleanly before becoming ready is not
+retried.  If every attempt fails, Retry returns the last Runner's error.
+
+A signal received before any Runner is ready aborts the retries: it is
+forwarded to the running attempt, and Retry returns that attempt's error, or
+the last attempt's error if it is waiting out the backoff.  After is used to
+wait out the backoff, and can be replaced to control the passage of time in
+tests.
+*/
+type Retry struct {
+	Factory     func() Runner
+	MaxAttempts int
+	Backoff     Backoff
+	After       func(time.Duration) <-chan time.Time
+}
+
+func (r Retry) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	after := r.After
+	if after == nil {
+		after = time.After
+	}
+
+	process := Background(r.Factory())
+	processReady := process.Ready()
+	exit := process.Wait()
+	attempts := 1
+	signaled := false
+
+	var lastErr error
+	var retry <-chan time.Time
+
+	for {
+		select {
+		case signal := <-signals:
+			if exit == nil {
+				return lastErr
+			}
+			process.Signal(signal)
+			signaled = true
+
+		case <-processReady:
+			processReady = nil
+			close(ready)
+
+		case lastErr = <-exit:
+			exit = nil
+			if becameReady(process) || signaled || lastErr == nil || attempts >= r.MaxAttempts {
+				return lastErr
+			}
+			processReady = nil
+			retry = after(r.Backoff.Next())
+
+		case <-retry:
+			retry = nil
+			attempts++
+			process = Background(r.Factory())
+			processReady = process.Ready()
+			exit = process.Wait()
+		}
+	}
+}
+
+// becameReady reports whether the process's ready channel has been closed,
+// even if it exited before the closed channel was noticed.
+func becameReady(process Process) bool {
+	select {
+	case <-process.Ready():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		runners chan *fake_runner.TestRunner
+		created []*fake_runner.TestRunner
+		mutex   sync.Mutex
+		delays  chan time.Duration
+		retry   ifrit.Retry
+		proc    ifrit.Process
+	)
+
+	BeforeEach(func() {
+		runners = make(chan *fake_runner.TestRunner, 10)
+		created = nil
+		delays = make(chan time.Duration, 10)
+
+		retry = ifrit.NewRetry(func() ifrit.Runner {
+			runner := fake_runner.NewTestRunner()
+			mutex.Lock()
+			created = append(created, runner)
+			mutex.Unlock()
+			runners <- runner
+			return runner
+		}, 3, &doublingBackoff{next: time.Millisecond}).(ifrit.Retry)
+		retry.After = func(d time.Duration) <-chan time.Time {
+			delays <- d
+			return time.After(0)
+		}
+	})
+
+	JustBeforeEach(func() {
+		proc = ifrit.Background(retry)
+	})
+
+	AfterEach(func() {
+		proc.Signal(os.Kill)
+		Eventually(func() ifrit.ProcessState {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, runner := range created {
+				runner.EnsureExit()
+			}
+			return proc.State()
+		}).Should(Equal(ifrit.StateExited))
+	})
+
+	nextRunner := func() *fake_runner.TestRunner {
+		var runner *fake_runner.TestRunner
+		Eventually(runners).Should(Receive(&runner))
+		return runner
+	}
+
+	It("retries with the backoff until a runner becomes ready", func() {
+		nextRunner().TriggerExit(errors.New("not yet"))
+		Eventually(delays).Should(Receive(Equal(1 * time.Millisecond)))
+		nextRunner().TriggerExit(errors.New("not yet"))
+		Eventually(delays).Should(Receive(Equal(2 * time.Millisecond)))
+
+		runner := nextRunner()
+		Consistently(proc.Ready()).ShouldNot(BeClosed())
+		runner.TriggerReady()
+		Eventually(proc.Ready()).Should(BeClosed())
+
+		runner.TriggerExit(errors.New("crashed"))
+		Eventually(proc.Wait()).Should(Receive(MatchError("crashed")))
+		Consistently(runners).ShouldNot(Receive())
+	})
+
+	It("returns the last error once every attempt has failed", func() {
+		nextRunner().TriggerExit(errors.New("attempt 1"))
+		nextRunner().TriggerExit(errors.New("attempt 2"))
+		nextRunner().TriggerExit(errors.New("attempt 3"))
+
+		Eventually(proc.Wait()).Should(Receive(MatchError("attempt 3")))
+		Ω(runners).ShouldNot(Receive())
+	})
+
+	It("does not retry a runner which exits cleanly", func() {
+		nextRunner().TriggerExit(nil)
+
+		Eventually(proc.Wait()).Should(Receive(BeNil()))
+		Ω(runners).ShouldNot(Receive())
+	})
+
+	Context("when signaled while an attempt is starting", func() {
+		It("forwards the signal, and returns the attempt's error without retrying", func() {
+			runner := nextRunner()
+			signals := runner.WaitForCall()
+
+			proc.Signal(os.Interrupt)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+			runner.TriggerExit(errors.New("interrupted"))
+
+			Eventually(proc.Wait()).Should(Receive(MatchError("interrupted")))
+			Ω(runners).ShouldNot(Receive())
+		})
+	})
+
+	Context("when signaled while waiting out the backoff", func() {
+		BeforeEach(func() {
+			retry.After = func(d time.Duration) <-chan time.Time {
+				delays <- d
+				return nil
+			}
+		})
+
+		It("returns the last attempt's error", func() {
+			nextRunner().TriggerExit(errors.New("not yet"))
+			Eventually(delays).Should(Receive())
+
+			proc.Signal(os.Interrupt)
+			Eventually(proc.Wait()).Should(Receive(MatchError("not yet")))
+			Ω(runners).ShouldNot(Receive())
+		})
+	})
+})
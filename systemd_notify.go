@@ -0,0 +1,64 @@
+package ifrit
+
+import (
+	"net"
+	"os"
+)
+
+/*
+WithSystemdNotify runs the inner Runner, reporting its lifecycle to systemd,
+for a service of Type=notify.  When the inner Runner becomes ready, READY=1 is
+sent to the socket named by the NOTIFY_SOCKET environment variable, and when the
+first signal is received, STOPPING=1 is sent before the signal is forwarded.
+
+If NOTIFY_SOCKET is not set, as when the service is not run by systemd, the
+inner Runner is run as it is.  A notification which can not be sent is dropped,
+since systemd is then no longer listening.
+*/
+func WithSystemdNotify(inner Runner) Runner {
+	return RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		socket := os.Getenv("NOTIFY_SOCKET")
+		if socket == "" {
+			return inner.Run(signals, ready)
+		}
+
+		process := Background(inner)
+		processReady := process.Ready()
+		exit := process.Wait()
+		stopping := false
+
+		for {
+			select {
+			case <-processReady:
+				processReady = nil
+				close(ready)
+				sdNotify(socket, "READY=1")
+
+			case signal := <-signals:
+				if !stopping {
+					stopping = true
+					sdNotify(socket, "STOPPING=1")
+				}
+				process.Signal(signal)
+
+			case err := <-exit:
+				return err
+			}
+		}
+	})
+}
+
+func sdNotify(socket string, state string) {
+	// A leading @ names a socket in the abstract namespace.
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	conn.Write([]byte(state))
+}
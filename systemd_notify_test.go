@@ -0,0 +1,104 @@
+package ifrit_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("WithSystemdNotify", func() {
+	var (
+		inner   *fake_runner.TestRunner
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		inner = fake_runner.NewTestRunner()
+	})
+
+	AfterEach(func() {
+		inner.EnsureExit()
+		os.Unsetenv("NOTIFY_SOCKET")
+	})
+
+	Context("when NOTIFY_SOCKET is set", func() {
+		var (
+			dir           string
+			notifications chan string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "sd-notify")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			socket := filepath.Join(dir, "notify.sock")
+			conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+			Ω(err).ShouldNot(HaveOccurred())
+			os.Setenv("NOTIFY_SOCKET", socket)
+
+			notifications = make(chan string, 10)
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 1024)
+				for {
+					n, err := conn.Read(buf)
+					if err != nil {
+						return
+					}
+					notifications <- string(buf[:n])
+					if string(buf[:n]) == "STOPPING=1" {
+						return
+					}
+				}
+			}()
+
+			process = ifrit.Background(ifrit.WithSystemdNotify(inner))
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("notifies systemd when ready, and when stopping", func() {
+			signals := inner.WaitForCall()
+			Consistently(notifications).ShouldNot(Receive())
+
+			inner.TriggerReady()
+			Eventually(process.Ready()).Should(BeClosed())
+			Eventually(notifications).Should(Receive(Equal("READY=1")))
+
+			process.Signal(os.Interrupt)
+			Eventually(notifications).Should(Receive(Equal("STOPPING=1")))
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+			inner.TriggerExit(nil)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+
+	Context("when NOTIFY_SOCKET is not set", func() {
+		BeforeEach(func() {
+			os.Unsetenv("NOTIFY_SOCKET")
+			process = ifrit.Background(ifrit.WithSystemdNotify(inner))
+		})
+
+		It("runs the inner runner as it is", func() {
+			signals := inner.WaitForCall()
+			inner.TriggerReady()
+			Eventually(process.Ready()).Should(BeClosed())
+
+			process.Signal(os.Interrupt)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+			inner.TriggerExit(nil)
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+	})
+})
@@ -0,0 +1,21 @@
+package ifrit
+
+import "time"
+
+/*
+WaitTimeout waits up to d for the Process to exit.  If it exits in time, its
+exit error is returned along with true.  Otherwise WaitTimeout returns a nil
+error and false.  Like Wait, it may be called any number of times, from any
+number of goroutines.
+*/
+func WaitTimeout(p Process, d time.Duration) (error, bool) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case err := <-p.Wait():
+		return err, true
+	case <-timer.C:
+		return nil, false
+	}
+}
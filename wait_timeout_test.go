@@ -0,0 +1,75 @@
+package ifrit_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/fake_runner"
+)
+
+var _ = Describe("WaitTimeout", func() {
+	var runner *fake_runner.TestRunner
+	var proc ifrit.Process
+
+	BeforeEach(func() {
+		runner = fake_runner.NewTestRunner()
+		proc = ifrit.Background(runner)
+		runner.TriggerReady()
+	})
+
+	AfterEach(func() {
+		runner.EnsureExit()
+	})
+
+	Context("when the process exits in time", func() {
+		BeforeEach(func() {
+			runner.TriggerExit(errors.New("boom"))
+		})
+
+		It("returns the exit error", func() {
+			err, exited := ifrit.WaitTimeout(proc, time.Second)
+			Ω(exited).Should(BeTrue())
+			Ω(err).Should(Equal(errors.New("boom")))
+		})
+
+		It("does not consume the exit for other waiters", func() {
+			ifrit.WaitTimeout(proc, time.Second)
+			Ω(<-proc.Wait()).Should(Equal(errors.New("boom")))
+		})
+	})
+
+	Context("when the process does not exit in time", func() {
+		It("times out", func() {
+			err, exited := ifrit.WaitTimeout(proc, 10*time.Millisecond)
+			Ω(exited).Should(BeFalse())
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	It("is safe to call from multiple goroutines", func() {
+		var wg sync.WaitGroup
+		results := make(chan bool, 3)
+
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, exited := ifrit.WaitTimeout(proc, time.Second)
+				results <- exited
+			}()
+		}
+
+		runner.TriggerExit(nil)
+		wg.Wait()
+		close(results)
+
+		for exited := range results {
+			Ω(exited).Should(BeTrue())
+		}
+	})
+})
@@ -0,0 +1,63 @@
+package ifrit
+
+import "os"
+
+/*
+A Job is a unit of work received by a Worker.
+*/
+type Job interface{}
+
+/*
+NewWorker returns a Runner which calls handle with each job received from jobs,
+one at a time.  It is ready at once, and returns nil once it is signaled, or
+once the jobs channel is closed.  A signal received while a job is being
+handled takes effect once the job is done.
+
+An error returned by handle does not stop the worker; use Worker directly to
+observe or stop on errors.
+*/
+func NewWorker(jobs <-chan Job, handle func(Job) error) Runner {
+	return Worker{
+		Jobs:   jobs,
+		Handle: handle,
+	}
+}
+
+/*
+Worker implements NewWorker.  If OnError is set, it is called with each job for
+which Handle returns an error.  If StopOnError is set, the worker instead
+returns the first such error.
+*/
+type Worker struct {
+	Jobs        <-chan Job
+	Handle      func(Job) error
+	OnError     func(Job, error)
+	StopOnError bool
+}
+
+func (w Worker) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	for {
+		select {
+		case <-signals:
+			return nil
+
+		case job, ok := <-w.Jobs:
+			if !ok {
+				return nil
+			}
+
+			err := w.Handle(job)
+			if err == nil {
+				continue
+			}
+			if w.StopOnError {
+				return err
+			}
+			if w.OnError != nil {
+				w.OnError(job, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ifrit_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Worker", func() {
+	var (
+		jobs    chan ifrit.Job
+		handled chan ifrit.Job
+		worker  ifrit.Worker
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		jobs = make(chan ifrit.Job)
+		handled = make(chan ifrit.Job, 10)
+		worker = ifrit.NewWorker(jobs, func(job ifrit.Job) error {
+			handled <- job
+			if job == "bad" {
+				return errors.New("bad job")
+			}
+			return nil
+		}).(ifrit.Worker)
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Background(worker)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Kill)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("is ready at once, and handles each job", func() {
+		Eventually(process.Ready()).Should(BeClosed())
+
+		jobs <- "one"
+		jobs <- "two"
+		Eventually(handled).Should(Receive(Equal("one")))
+		Eventually(handled).Should(Receive(Equal("two")))
+	})
+
+	It("returns nil when signaled", func() {
+		jobs <- "one"
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("returns nil when the jobs channel is closed", func() {
+		jobs <- "one"
+		close(jobs)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+		Ω(handled).Should(Receive(Equal("one")))
+	})
+
+	Context("when a job fails", func() {
+		var failures chan error
+
+		BeforeEach(func() {
+			failures = make(chan error, 10)
+			worker.OnError = func(job ifrit.Job, err error) {
+				failures <- err
+			}
+		})
+
+		It("reports the error, and keeps working", func() {
+			jobs <- "bad"
+			Eventually(failures).Should(Receive(MatchError("bad job")))
+
+			jobs <- "good"
+			Eventually(handled).Should(Receive(Equal("bad")))
+			Eventually(handled).Should(Receive(Equal("good")))
+			Ω(process.State()).Should(Equal(ifrit.StateReady))
+		})
+
+		Context("and the worker stops on errors", func() {
+			BeforeEach(func() {
+				worker.StopOnError = true
+			})
+
+			It("returns the error", func() {
+				jobs <- "bad"
+				Eventually(process.Wait()).Should(Receive(MatchError("bad job")))
+				Ω(failures).ShouldNot(Receive())
+			})
+		})
+	})
+})